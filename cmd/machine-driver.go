@@ -4,7 +4,9 @@ import (
 	"os"
 	"path"
 
+	"github.com/docker/machine/commands/mcndirs"
 	"github.com/docker/machine/libmachine/drivers/plugin"
+	"github.com/docker/machine/libmachine/log"
 	//	"github.com/fideltak/docker-machine-driver-ov/driver"
 	"github.com/HPE-Japan-Presales/docker-machine-driver-ov/driver"
 	"github.com/urfave/cli"
@@ -35,5 +37,154 @@ func main() {
 	app.Action = func(c *cli.Context) {
 		plugin.RegisterDriver(driver.NewDriver("", ""))
 	}
+	app.Commands = []cli.Command{
+		{
+			Name:      "reprovision",
+			Usage:     "Reinstall OS of machine on same server profile. IP address and hostname are kept.",
+			ArgsUsage: "MACHINE_NAME",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "storage-path, s",
+					Usage:  "Docker machine store path",
+					EnvVar: "MACHINE_STORAGE_PATH",
+					Value:  mcndirs.GetBaseDir(),
+				},
+				cli.BoolFlag{
+					Name:   "debug, D",
+					Usage:  "Debug mode",
+					EnvVar: "OV_DEBUG",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return cli.NewExitError("Specify one machine name", 1)
+				}
+				log.SetDebug(c.Bool("debug"))
+				if err := driver.ReprovisionMachine(c.String("storage-path"), c.Args().First()); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "release-hardware",
+			Usage:     "Remove reservation label of machine from server hardware, such as label left by create on other host",
+			ArgsUsage: "SERVER_HARDWARE_NAME",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "oneview-endpoint",
+					Usage:  "HPE OneView endpoint",
+					EnvVar: "OV_ONEVIEW_ENDPOINT",
+				},
+				cli.StringFlag{
+					Name:   "oneview-user",
+					Usage:  "HPE OneView user",
+					EnvVar: "OV_ONEVIEW_USER",
+				},
+				cli.StringFlag{
+					Name:   "oneview-password",
+					Usage:  "HPE OneView password",
+					EnvVar: "OV_ONEVIEW_PASSWORD",
+				},
+				cli.StringFlag{
+					Name:   "oneview-domain",
+					Usage:  "HPE OneView domain",
+					EnvVar: "OV_ONEVIEW_DOMAIN",
+				},
+				cli.StringFlag{
+					Name:   "tls-ca-cert",
+					Usage:  "CA bundle to verify HPE OneView certificate",
+					EnvVar: "OV_TLS_CA_CERT",
+				},
+				cli.StringFlag{
+					Name:   "tls-oneview-fingerprint",
+					Usage:  "Pinned SHA-256 fingerprint of HPE OneView certificate",
+					EnvVar: "OV_TLS_ONEVIEW_FINGERPRINT",
+				},
+				cli.BoolFlag{
+					Name:   "tls-insecure",
+					Usage:  "Skip verification of HPE OneView certificate",
+					EnvVar: "OV_TLS_INSECURE",
+				},
+				cli.StringFlag{
+					Name:  "server-profile",
+					Usage: "Server profile name of machine which reserved server hardware, such as ov-docker-machine-node1",
+				},
+				cli.BoolFlag{
+					Name:   "debug, D",
+					Usage:  "Debug mode",
+					EnvVar: "OV_DEBUG",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 || c.String("server-profile") == "" {
+					return cli.NewExitError("Specify server hardware name and server profile of reservation", 1)
+				}
+				log.SetDebug(c.Bool("debug"))
+				o := &driver.Oneview{
+					Endpoint:          c.String("oneview-endpoint"),
+					Username:          c.String("oneview-user"),
+					Password:          c.String("oneview-password"),
+					Domain:            c.String("oneview-domain"),
+					ServerProfileName: c.String("server-profile"),
+					Tls: &driver.TlsConfig{
+						CaCert:      c.String("tls-ca-cert"),
+						Fingerprint: c.String("tls-oneview-fingerprint"),
+						Insecure:    c.Bool("tls-insecure"),
+					},
+				}
+				if err := o.ReleaseServerHardware(c.Args().First()); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "remaster",
+			Usage:     "Write installer ISO which boots kickstart on labeled image. Boot menus are patched and BIOS/UEFI boot images are kept.",
+			ArgsUsage: "SOURCE_ISO DESTINATION_ISO",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "kickstart-label",
+					Usage: "Volume label of kickstart image",
+					Value: "ov-ks",
+				},
+				cli.StringFlag{
+					Name:  "kickstart-url",
+					Usage: "URL of kickstart file, such as for UEFI HTTP boot. Kickstart label is not used if specified",
+				},
+				cli.StringFlag{
+					Name:  "volume-label",
+					Usage: "Volume label of remastered image. Label of source image is kept if empty",
+				},
+				cli.IntFlag{
+					Name:  "timeout",
+					Usage: "Boot menu timeout in seconds. Timeout of source image is kept if negative",
+					Value: 5,
+				},
+				cli.BoolFlag{
+					Name:   "debug, D",
+					Usage:  "Debug mode",
+					EnvVar: "OV_DEBUG",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 2 {
+					return cli.NewExitError("Specify source and destination ISO image", 1)
+				}
+				log.SetDebug(c.Bool("debug"))
+				opts := &driver.RemasterOptions{
+					KsLabel:     c.String("kickstart-label"),
+					KsUrl:       c.String("kickstart-url"),
+					VolumeLabel: c.String("volume-label"),
+					Timeout:     c.Int("timeout"),
+				}
+				if err := driver.RemasterIso(c.Args().Get(0), c.Args().Get(1), opts); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				return nil
+			},
+		},
+	}
 	app.Run(os.Args)
 }
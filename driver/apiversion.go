@@ -0,0 +1,77 @@
+package driver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	apiVersionAuto = "auto"
+)
+
+// HPE OneView API versions which oneview-golang supports
+var supportedApiVersions = []int{200, 300, 500, 600, 800, 1000, 1200, 1600, 1800, 2000, 2200, 2400}
+
+// Decide API version from setting and versions of appliance.
+// "auto" or empty setting selects highest version which both appliance and client support.
+func negotiateApiVersion(setting string, appliance ov.APIVersion) (int, error) {
+	setting = strings.TrimSpace(setting)
+	if setting == "" || strings.ToLower(setting) == apiVersionAuto {
+		for i := len(supportedApiVersions) - 1; i >= 0; i-- {
+			version := supportedApiVersions[i]
+			if version <= appliance.CurrentVersion && version >= appliance.MinimumVersion {
+				return version, nil
+			}
+		}
+		err := fmt.Errorf("No API version is supported by both HPE OneView (%d to %d) and this driver (%d to %d)",
+			appliance.MinimumVersion, appliance.CurrentVersion, supportedApiVersions[0], supportedApiVersions[len(supportedApiVersions)-1])
+		return 0, err
+	}
+
+	version, err := strconv.Atoi(setting)
+	if err != nil {
+		err := fmt.Errorf("Invalid HPE OneView API version: %s. Specify number or %s", setting, apiVersionAuto)
+		return 0, err
+	}
+	if !isSupportedApiVersion(version) {
+		err := fmt.Errorf("HPE OneView API version %d is not supported by this driver. Supported versions are %v", version, supportedApiVersions)
+		return 0, err
+	}
+	if version > appliance.CurrentVersion || version < appliance.MinimumVersion {
+		err := fmt.Errorf("HPE OneView API version %d is not supported by appliance. Supported versions are %d to %d", version, appliance.MinimumVersion, appliance.CurrentVersion)
+		return 0, err
+	}
+	return version, nil
+}
+
+func isSupportedApiVersion(version int) bool {
+	for _, v := range supportedApiVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Read /rest/version and set API version to client
+func (o *Oneview) resolveApiVersion(ovc *ov.OVClient) error {
+	log.Debugf("Trying to connect HPE OneView endpoint at %v to negotiate API version", o.Endpoint)
+	appliance, err := ovc.GetAPIVersion()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	version, err := negotiateApiVersion(o.ApiVersionSetting, appliance)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Debugf("HPE OneView API version %d is selected from %q. Appliance supports %d to %d", version, o.ApiVersionSetting, appliance.MinimumVersion, appliance.CurrentVersion)
+	o.ApiVersion = version
+	ovc.APIVersion = version
+	return nil
+}
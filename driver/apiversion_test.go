@@ -0,0 +1,92 @@
+package driver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"gopkg.in/yaml.v2"
+)
+
+func TestApiVersionNegotiate(t *testing.T) {
+	appliance := ov.APIVersion{CurrentVersion: 2000, MinimumVersion: 120}
+	cases := []struct {
+		setting string
+		version int
+		isError bool
+	}{
+		{setting: "auto", version: 2000},
+		{setting: "", version: 2000},
+		{setting: "1200", version: 1200},
+		{setting: "2400", isError: true}, // Newer than appliance
+		{setting: "1300", isError: true}, // Unknown version
+		{setting: "latest", isError: true},
+	}
+	for _, c := range cases {
+		version, err := negotiateApiVersion(c.setting, appliance)
+		if c.isError {
+			if err == nil {
+				t.Errorf("%q should be error but got %d", c.setting, version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", c.setting, err)
+			continue
+		}
+		if version != c.version {
+			t.Errorf("%q: expected %d, got %d", c.setting, c.version, version)
+		}
+	}
+
+	// Appliance newer than client
+	version, err := negotiateApiVersion("auto", ov.APIVersion{CurrentVersion: 9000, MinimumVersion: 800})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != supportedApiVersions[len(supportedApiVersions)-1] {
+		t.Errorf("Highest client version should be selected: %d", version)
+	}
+}
+
+func TestApiVersionResolve(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"currentVersion": 1800, "minimumVersion": 120}`))
+	}))
+	defer ts.Close()
+
+	o := &Oneview{Endpoint: ts.URL, ApiVersionSetting: "auto"}
+	ovc, err := o.newOVClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.resolveApiVersion(ovc); err != nil {
+		t.Fatal(err)
+	}
+	if o.ApiVersion != 1800 || ovc.APIVersion != 1800 {
+		t.Errorf("API version is not negotiated: %d %d", o.ApiVersion, ovc.APIVersion)
+	}
+
+	o = &Oneview{Endpoint: ts.URL, ApiVersionSetting: "2000"}
+	if ovc, err = o.newOVClient(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.resolveApiVersion(ovc); err == nil {
+		t.Errorf("Version newer than appliance should be error")
+	}
+}
+
+func TestApiVersionYaml(t *testing.T) {
+	var conf HpeConfig
+	if err := yaml.Unmarshal([]byte("oneview:\n  api-version: 1200\n"), &conf); err != nil {
+		t.Fatal(err)
+	}
+	if conf.Oneview.ApiVersionSetting != "1200" {
+		t.Errorf("Numeric API version should be read: %q", conf.Oneview.ApiVersionSetting)
+	}
+}
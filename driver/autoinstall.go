@@ -0,0 +1,96 @@
+package driver
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	cidataLabel      = "CIDATA"
+	userDataFileName = "user-data"
+	metaDataFileName = "meta-data"
+)
+
+// Used when autoinstall template is not specified.
+// Root login is enabled so that installed server is handled same as kickstart.
+// Password of ubuntu user is locked when root password is not specified.
+const defaultAutoinstallTemplate = `#cloud-config
+autoinstall:
+  version: 1
+  locale: en_US.UTF-8
+  keyboard:
+    layout: us
+  timezone: UTC
+  network:
+    version: 2
+    ethernets:
+      primary:
+        match:
+          name: "en*"
+        addresses: ["{{.Address}}/{{.PrefixLength}}"]
+{{- if .Gateway}}
+        routes:
+          - to: 0.0.0.0/0
+            via: "{{.Gateway}}"
+{{- end}}
+{{- if .DnsServers}}
+        nameservers:
+          addresses: [{{join .DnsServers ", "}}]
+{{- if .Domain}}
+          search: ["{{.Domain}}"]
+{{- end}}
+{{- end}}
+  identity:
+    hostname: "{{.Hostname}}"
+    username: ubuntu
+    password: '{{or .RootPasswordHash "!"}}'
+  ssh:
+    install-server: true
+    allow-pw: {{if .RootPasswordHash}}true{{else}}false{{end}}
+{{- if .SshPublicKey}}
+    authorized-keys:
+      - "{{.SshPublicKey}}"
+{{- end}}
+  storage:
+    layout:
+      name: lvm
+  late-commands:
+{{- if .RootPasswordHash}}
+    - "curtin in-target --target=/target -- usermod -p '{{.RootPasswordHash}}' root"
+{{- end}}
+    - "sed -i 's/^#\\?PermitRootLogin .*/PermitRootLogin {{if .RootPasswordHash}}yes{{else}}prohibit-password{{end}}/' /target/etc/ssh/sshd_config"
+{{- if .SshPublicKey}}
+    - "mkdir -p -m 0700 /target/root/.ssh"
+    - "echo '{{.SshPublicKey}}' >> /target/root/.ssh/authorized_keys"
+    - "chmod 0600 /target/root/.ssh/authorized_keys"
+{{- end}}
+    - "echo '{{.Address}}  {{.Fqdn}}  {{.Hostname}}' >> /target/etc/hosts"
+`
+
+// meta-data of NoCloud seed
+func autoinstallMetaData(s *Server) map[string][]byte {
+	metaData := fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", s.Hostname, s.Hostname)
+	return map[string][]byte{metaDataFileName: []byte(metaData)}
+}
+
+// cloud-init reads user-data only when it is YAML starting with #cloud-config
+func validateUserData(userData []byte) error {
+	if !strings.HasPrefix(string(userData), "#cloud-config\n") {
+		return fmt.Errorf("user-data should start with #cloud-config")
+	}
+	var config struct {
+		Autoinstall map[string]interface{} `yaml:"autoinstall"`
+	}
+	if err := yaml.Unmarshal(userData, &config); err != nil {
+		return fmt.Errorf("user-data is not valid YAML: %v", err)
+	}
+	if config.Autoinstall == nil {
+		return fmt.Errorf("user-data does not have autoinstall section")
+	}
+	if _, ok := config.Autoinstall["version"]; !ok {
+		return fmt.Errorf("version is required in autoinstall section")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package driver
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestAutoinstallSeed(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, "")
+	defer cleanup()
+	s.InstallMethod = installMethodAutoinstall
+
+	if err := s.validateInstallMethod(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.validateKickstart(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.GenerateKickstart(); err != nil {
+		t.Fatal(err)
+	}
+	src, err := openIsoSource(filepath.Join(s.KsOutputDir, "192.168.1.10.iso"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	if src.label != cidataLabel {
+		t.Errorf("Volume label of NoCloud seed should be %s: %s", cidataLabel, src.label)
+	}
+
+	metaData := string(readTestIsoFile(t, src, metaDataFileName))
+	if metaData != "instance-id: node1\nlocal-hostname: node1\n" {
+		t.Errorf("Unexpected meta-data: %s", metaData)
+	}
+	var userData struct {
+		Autoinstall struct {
+			Network struct {
+				Ethernets map[string]struct {
+					Addresses   []string `yaml:"addresses"`
+					Nameservers struct {
+						Addresses []string `yaml:"addresses"`
+						Search    []string `yaml:"search"`
+					} `yaml:"nameservers"`
+				} `yaml:"ethernets"`
+			} `yaml:"network"`
+			Ssh struct {
+				AuthorizedKeys []string `yaml:"authorized-keys"`
+			} `yaml:"ssh"`
+			LateCommands []string `yaml:"late-commands"`
+		} `yaml:"autoinstall"`
+	}
+	if err := yaml.Unmarshal(readTestIsoFile(t, src, userDataFileName), &userData); err != nil {
+		t.Fatal(err)
+	}
+	ethernet := userData.Autoinstall.Network.Ethernets["primary"]
+	if len(ethernet.Addresses) != 1 || ethernet.Addresses[0] != "192.168.1.10/24" ||
+		strings.Join(ethernet.Nameservers.Addresses, ",") != "192.168.1.2,192.168.1.3" || ethernet.Nameservers.Search[0] != "example.com" {
+		t.Errorf("Unexpected network config: %+v", ethernet)
+	}
+	if len(userData.Autoinstall.Ssh.AuthorizedKeys) != 1 || userData.Autoinstall.Ssh.AuthorizedKeys[0] != s.SshPublicKey {
+		t.Errorf("SSH public key is not embedded: %v", userData.Autoinstall.Ssh.AuthorizedKeys)
+	}
+	// Root login is enabled like kickstart
+	if !strings.Contains(strings.Join(userData.Autoinstall.LateCommands, "\n"), "echo '"+s.SshPublicKey+"' >> /target/root/.ssh/authorized_keys") {
+		t.Errorf("SSH public key is not registered for root: %v", userData.Autoinstall.LateCommands)
+	}
+}
+
+func TestAutoinstallInvalid(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatFat)
+	defer cleanup()
+	s.InstallMethod = installMethodAutoinstall
+	if err := s.validateKickstart(); err == nil {
+		t.Error("FAT image should be error for NoCloud seed")
+	}
+	s.KsFormat = ksFormatIso
+	s.KsLabel = "ov-ks"
+	if err := s.validateKickstart(); err == nil {
+		t.Error("Label other than CIDATA should be error")
+	}
+	s.KsLabel = ""
+	s.KsOutputDir = ""
+	if err := s.validateInstallMethod(); err == nil {
+		t.Error("Autoinstall without generated seed should be error")
+	}
+	s.InstallMethod = "preseed"
+	if err := s.validateInstallMethod(); err == nil {
+		t.Error("Unknown install method should be error")
+	}
+
+	for _, userData := range []string{
+		"autoinstall:\n  version: 1\n",
+		"#cloud-config\nautoinstall: [\n",
+		"#cloud-config\nusers: []\n",
+		"#cloud-config\nautoinstall:\n  locale: en_US.UTF-8\n",
+	} {
+		if err := validateUserData([]byte(userData)); err == nil {
+			t.Errorf("Invalid user-data should be error: %q", userData)
+		}
+	}
+}
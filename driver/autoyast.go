@@ -0,0 +1,108 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const (
+	oemdrvLabel      = "OEMDRV"
+	autoyastFileName = "autoinst.xml"
+	autoyastRoot     = "profile"
+)
+
+// Used when AutoYaST template is not specified.
+// Installer of SLES 15 and openSUSE Leap reads autoinst.xml on device labeled OEMDRV.
+const defaultAutoyastTemplate = `<?xml version="1.0"?>
+<!DOCTYPE profile>
+<profile xmlns="http://www.suse.com/1.0/yast2ns" xmlns:config="http://www.suse.com/1.0/configns">
+  <general>
+    <mode>
+      <confirm config:type="boolean">false</confirm>
+      <final_reboot config:type="boolean">true</final_reboot>
+    </mode>
+  </general>
+  <networking>
+    <keep_install_network config:type="boolean">false</keep_install_network>
+    <dns>
+      <hostname>{{xml .Hostname}}</hostname>
+{{- if .Domain}}
+      <domain>{{xml .Domain}}</domain>
+{{- end}}
+{{- if .DnsServers}}
+      <nameservers config:type="list">
+{{- range .DnsServers}}
+        <nameserver>{{xml .}}</nameserver>
+{{- end}}
+      </nameservers>
+{{- end}}
+    </dns>
+    <interfaces config:type="list">
+      <interface>
+        <name>eth0</name>
+        <bootproto>static</bootproto>
+        <ipaddr>{{xml .Address}}</ipaddr>
+        <netmask>{{xml .Netmask}}</netmask>
+        <startmode>auto</startmode>
+      </interface>
+    </interfaces>
+{{- if .Gateway}}
+    <routing>
+      <routes config:type="list">
+        <route>
+          <destination>default</destination>
+          <gateway>{{xml .Gateway}}</gateway>
+          <device>-</device>
+        </route>
+      </routes>
+    </routing>
+{{- end}}
+  </networking>
+  <firewall>
+    <enable_firewall config:type="boolean">false</enable_firewall>
+  </firewall>
+  <services-manager>
+    <services>
+      <enable config:type="list">
+        <service>sshd</service>
+      </enable>
+    </services>
+  </services-manager>
+  <users config:type="list">
+    <user>
+      <username>root</username>
+      <encrypted config:type="boolean">true</encrypted>
+      <user_password>{{xml (or .RootPasswordHash "!")}}</user_password>
+{{- if .SshPublicKey}}
+      <authorized_keys config:type="list">
+        <listentry>{{xml .SshPublicKey}}</listentry>
+      </authorized_keys>
+{{- end}}
+    </user>
+  </users>
+</profile>
+`
+
+// Check autoinst.xml is well-formed XML with profile root element
+func validateAutoyast(config []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(config))
+	root := ""
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%s is not valid XML: %v", autoyastFileName, err)
+		}
+		if start, ok := token.(xml.StartElement); ok && root == "" {
+			root = start.Name.Local
+		}
+	}
+	if root != autoyastRoot {
+		return fmt.Errorf("Root element of %s should be %s: %s", autoyastFileName, autoyastRoot, root)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package driver
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+)
+
+func TestAutoyastProfile(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatFat)
+	defer cleanup()
+	s.InstallMethod = installMethodAutoyast
+	s.Hostname = "node<1>"
+
+	if err := s.validateKickstart(); err != nil {
+		t.Fatal(err)
+	}
+	config, err := s.RenderKickstart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var profile struct {
+		XMLName    xml.Name `xml:"profile"`
+		Networking struct {
+			Hostname   string   `xml:"dns>hostname"`
+			Nameserver []string `xml:"dns>nameservers>nameserver"`
+			Address    string   `xml:"interfaces>interface>ipaddr"`
+			Netmask    string   `xml:"interfaces>interface>netmask"`
+			Gateway    string   `xml:"routing>routes>route>gateway"`
+		} `xml:"networking"`
+		Users []struct {
+			Username       string   `xml:"username"`
+			AuthorizedKeys []string `xml:"authorized_keys>listentry"`
+		} `xml:"users>user"`
+	}
+	if err := xml.Unmarshal(config, &profile); err != nil {
+		t.Fatal(err)
+	}
+	n := profile.Networking
+	// Values are escaped in XML
+	if n.Hostname != "node<1>" || n.Address != "192.168.1.10" || n.Netmask != "255.255.255.0" || n.Gateway != "192.168.1.1" || len(n.Nameserver) != 2 {
+		t.Errorf("Unexpected networking: %+v", n)
+	}
+	if len(profile.Users) != 1 || profile.Users[0].Username != "root" || profile.Users[0].AuthorizedKeys[0] != s.SshPublicKey {
+		t.Errorf("Unexpected users: %+v", profile.Users)
+	}
+
+	// autoinst.xml is 8.3 name, so FAT image can be used
+	if err := s.GenerateKickstart(); err != nil {
+		t.Fatal(err)
+	}
+	if s.kickstartLabel() != oemdrvLabel || filepath.Base(s.KsUrl) != "192.168.1.10.img" {
+		t.Errorf("Unexpected image: %s %s", s.kickstartLabel(), s.KsUrl)
+	}
+}
+
+func TestAutoyastInvalid(t *testing.T) {
+	for _, config := range []string{
+		"<profile><general></profile>",
+		"<?xml version=\"1.0\"?>\n<users/>",
+		"",
+	} {
+		if err := validateAutoyast([]byte(config)); err == nil {
+			t.Errorf("Invalid autoinst.xml should be error: %q", config)
+		}
+	}
+}
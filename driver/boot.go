@@ -0,0 +1,218 @@
+package driver
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/docker/machine/libmachine/log"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const defaultBootTarget = string(redfish.CdBootSourceOverrideTarget)
+
+// Boot property of computer system with capabilities
+type IloBoot struct {
+	BootSourceOverrideEnabled    string                `json:"BootSourceOverrideEnabled"`
+	BootSourceOverrideTarget     string                `json:"BootSourceOverrideTarget"`
+	BootSourceOverrideMode       string                `json:"BootSourceOverrideMode"`
+	UefiTargetBootSourceOverride string                `json:"UefiTargetBootSourceOverride"`
+	BootNext                     string                `json:"BootNext"`
+	AllowableTargets             []string              `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+	BootOptions                  IloVirtualMediaMember `json:"BootOptions"`
+}
+
+// UEFI boot option of computer system
+type IloBootOption struct {
+	Id                  string `json:"@odata.id"`
+	DisplayName         string `json:"DisplayName"`
+	UefiDevicePath      string `json:"UefiDevicePath"`
+	BootOptionReference string `json:"BootOptionReference"`
+}
+
+// Target is case insensitive in options
+func parseBootTarget(target string) (redfish.BootSourceOverrideTarget, error) {
+	if target == "" {
+		return redfish.CdBootSourceOverrideTarget, nil
+	}
+	for _, t := range []redfish.BootSourceOverrideTarget{
+		redfish.CdBootSourceOverrideTarget,
+		redfish.UefiTargetBootSourceOverrideTarget,
+		redfish.UefiBootNextBootSourceOverrideTarget,
+		redfish.UefiHTTPBootSourceOverrideTarget,
+	} {
+		if strings.EqualFold(target, string(t)) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown boot target: %s. Specify %s, %s, %s or %s", target,
+		redfish.CdBootSourceOverrideTarget, redfish.UefiTargetBootSourceOverrideTarget, redfish.UefiBootNextBootSourceOverrideTarget,
+		redfish.UefiHTTPBootSourceOverrideTarget)
+}
+
+// Virtual CD/DVD of iLO in UEFI boot options
+func isVirtualCdBootOption(option *IloBootOption) bool {
+	name := strings.ToLower(option.DisplayName)
+	return strings.Contains(name, "virtual") && (strings.Contains(name, "cd") || strings.Contains(name, "dvd"))
+}
+
+// One-time boot setting which boots from virtual CD
+func oneTimeBoot(target redfish.BootSourceOverrideTarget, option *IloBootOption) redfish.Boot {
+	boot := redfish.Boot{
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  target,
+	}
+	switch target {
+	case redfish.UefiTargetBootSourceOverrideTarget:
+		boot.UefiTargetBootSourceOverride = option.UefiDevicePath
+	case redfish.UefiBootNextBootSourceOverrideTarget:
+		boot.BootNext = option.BootOptionReference
+	}
+	return boot
+}
+
+// Check current boot property has expected one-time boot
+func checkOneTimeBoot(expected redfish.Boot, current *IloBoot) error {
+	if current.BootSourceOverrideEnabled != string(expected.BootSourceOverrideEnabled) ||
+		current.BootSourceOverrideTarget != string(expected.BootSourceOverrideTarget) {
+		return fmt.Errorf("One-time boot is not set: BootSourceOverrideEnabled=%s BootSourceOverrideTarget=%s",
+			current.BootSourceOverrideEnabled, current.BootSourceOverrideTarget)
+	}
+	if expected.UefiTargetBootSourceOverride != "" && current.UefiTargetBootSourceOverride != expected.UefiTargetBootSourceOverride {
+		return fmt.Errorf("UEFI target of one-time boot is %s, not %s", current.UefiTargetBootSourceOverride, expected.UefiTargetBootSourceOverride)
+	}
+	if expected.BootNext != "" && current.BootNext != expected.BootNext {
+		return fmt.Errorf("BootNext of one-time boot is %s, not %s", current.BootNext, expected.BootNext)
+	}
+	return nil
+}
+
+// Computer system URI and its boot property
+func (ilo *IloClient) getSystemBoot(c *gofish.APIClient) (string, *IloBoot, error) {
+	systems, err := c.Service.Systems()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(systems) == 0 {
+		return "", nil, fmt.Errorf("Could not find computer system on HPE iLO %s", ilo.Address)
+	}
+	var system struct {
+		Boot IloBoot `json:"Boot"`
+	}
+	if err := ilo.getRedfishResource(c, systems[0].ODataID, &system); err != nil {
+		return "", nil, err
+	}
+	log.Debugf("Boot: %#v", system.Boot)
+	return systems[0].ODataID, &system.Boot, nil
+}
+
+// Find virtual CD in UEFI boot options
+func (ilo *IloClient) findVirtualCdBootOption(c *gofish.APIClient, boot *IloBoot) (*IloBootOption, error) {
+	if boot.BootOptions.Id == "" {
+		return nil, fmt.Errorf("Computer system on HPE iLO %s does not have UEFI boot options", ilo.Address)
+	}
+	var options IloVirtualMedias
+	if err := ilo.getRedfishResource(c, boot.BootOptions.Id, &options); err != nil {
+		return nil, err
+	}
+	for _, member := range options.Members {
+		var option IloBootOption
+		if err := ilo.getRedfishResource(c, member.Id, &option); err != nil {
+			return nil, err
+		}
+		if isVirtualCdBootOption(&option) {
+			log.Infof("Virtual CD boot option: %s (%s %s)", option.DisplayName, option.BootOptionReference, option.UefiDevicePath)
+			return &option, nil
+		}
+	}
+	return nil, fmt.Errorf("Virtual CD is not found in UEFI boot options. Insert virtual media before setting one-time boot")
+}
+
+// Expected one-time boot for target. UEFI boot option of virtual CD is looked up for UefiTarget and UefiBootNext.
+func (ilo *IloClient) oneTimeBoot(c *gofish.APIClient, target redfish.BootSourceOverrideTarget) (string, redfish.Boot, error) {
+	uri, current, err := ilo.getSystemBoot(c)
+	if err != nil {
+		return "", redfish.Boot{}, err
+	}
+	if len(current.AllowableTargets) > 0 {
+		allowed := false
+		for _, t := range current.AllowableTargets {
+			if t == string(target) {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return "", redfish.Boot{}, fmt.Errorf("Boot target %s is not supported. Supported targets are %s", target, strings.Join(current.AllowableTargets, ", "))
+		}
+	}
+	var option *IloBootOption
+	switch target {
+	case redfish.UefiTargetBootSourceOverrideTarget, redfish.UefiBootNextBootSourceOverrideTarget:
+		if option, err = ilo.findVirtualCdBootOption(c, current); err != nil {
+			return "", redfish.Boot{}, err
+		}
+	}
+	return uri, oneTimeBoot(target, option), nil
+}
+
+// Boot from target device only on next boot.
+// Setting is read again and checked because some iLO versions ignore unknown values.
+func (ilo *IloClient) SetOneTimeBoot(target redfish.BootSourceOverrideTarget) error {
+	c, err := ilo.createRedfishClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer c.Logout()
+
+	uri, boot, err := ilo.oneTimeBoot(c, target)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Infof("Set one-time boot to %s", target)
+	req := struct {
+		Boot redfish.Boot
+	}{
+		Boot: boot,
+	}
+	if _, err := c.Patch(uri, req); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	_, current, err := ilo.getSystemBoot(c)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := checkOneTimeBoot(boot, current); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Infof("One-time boot is set: BootSourceOverrideEnabled=%s BootSourceOverrideTarget=%s BootSourceOverrideMode=%s",
+		current.BootSourceOverrideEnabled, current.BootSourceOverrideTarget, current.BootSourceOverrideMode)
+	return nil
+}
+
+// Check one-time boot to target is still set
+func (ilo *IloClient) CheckOneTimeBoot(target redfish.BootSourceOverrideTarget) error {
+	c, err := ilo.createRedfishClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer c.Logout()
+
+	_, boot, err := ilo.oneTimeBoot(c, target)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	_, current, err := ilo.getSystemBoot(c)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return checkOneTimeBoot(boot, current)
+}
@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+func createTestRedfishIloClient(t *testing.T) (*IloClient, func()) {
+	ts, _ := createTestRedfishServer(t)
+	ilo := &IloClient{
+		Address: strings.TrimPrefix(ts.URL, "https://"),
+		Token:   "token",
+		Model:   "iLO5",
+		Tls:     &TlsConfig{Insecure: true},
+	}
+	return ilo, ts.Close
+}
+
+func TestBootTarget(t *testing.T) {
+	for option, expected := range map[string]redfish.BootSourceOverrideTarget{
+		"":             redfish.CdBootSourceOverrideTarget,
+		"cd":           redfish.CdBootSourceOverrideTarget,
+		"UefiTarget":   redfish.UefiTargetBootSourceOverrideTarget,
+		"uefibootnext": redfish.UefiBootNextBootSourceOverrideTarget,
+		"UefiHttp":     redfish.UefiHTTPBootSourceOverrideTarget,
+	} {
+		target, err := parseBootTarget(option)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if target != expected {
+			t.Errorf("Boot target of %q should be %s: %s", option, expected, target)
+		}
+	}
+	if _, err := parseBootTarget("Pxe"); err == nil {
+		t.Error("Pxe should not be boot target")
+	}
+}
+
+func TestBootOneTime(t *testing.T) {
+	ilo, cleanup := createTestRedfishIloClient(t)
+	defer cleanup()
+
+	if err := ilo.CheckOneTimeBoot(redfish.CdBootSourceOverrideTarget); err == nil {
+		t.Error("One-time boot should not be set yet")
+	}
+	for _, target := range []redfish.BootSourceOverrideTarget{
+		redfish.CdBootSourceOverrideTarget,
+		redfish.UefiTargetBootSourceOverrideTarget,
+		redfish.UefiBootNextBootSourceOverrideTarget,
+	} {
+		if err := ilo.SetOneTimeBoot(target); err != nil {
+			t.Fatal(err)
+		}
+		if err := ilo.CheckOneTimeBoot(target); err != nil {
+			t.Errorf("One-time boot to %s is not checked: %v", target, err)
+		}
+	}
+
+	// Virtual CD is found in UEFI boot options
+	c, err := ilo.createRedfishClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Logout()
+	_, current, err := ilo.getSystemBoot(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.BootNext != "Boot000A" || current.UefiTargetBootSourceOverride != "PciRoot(0x0)/Pci(0x14,0x0)/USB(0x13,0x0)/USB(0x0,0x0)/USB(0x1,0x0)" {
+		t.Errorf("Unexpected UEFI one-time boot: %#v", current)
+	}
+}
+
+func TestBootOneTimeIgnored(t *testing.T) {
+	expected := oneTimeBoot(redfish.UefiBootNextBootSourceOverrideTarget, &IloBootOption{BootOptionReference: "Boot000A"})
+	for _, current := range []*IloBoot{
+		{BootSourceOverrideEnabled: "Disabled", BootSourceOverrideTarget: "UefiBootNext", BootNext: "Boot000A"},
+		{BootSourceOverrideEnabled: "Once", BootSourceOverrideTarget: "Hdd"},
+		{BootSourceOverrideEnabled: "Once", BootSourceOverrideTarget: "UefiBootNext", BootNext: "Boot0009"},
+	} {
+		if err := checkOneTimeBoot(expected, current); err == nil {
+			t.Errorf("Unexpected one-time boot should be error: %#v", current)
+		}
+	}
+}
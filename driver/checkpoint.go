@@ -0,0 +1,96 @@
+package driver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	checkpointFileName = "ov-checkpoint.json"
+)
+
+// Provisioning stages of Create in execution order
+const (
+	stageNone             = ""
+	stageIpAllocated      = "ip-allocated"
+	stageKickstartCreated = "kickstart-created"
+	stageHardwareReserved = "hardware-reserved"
+	stageProfileCreated   = "profile-created"
+	stageMediaInserted    = "media-inserted"
+	stageBootOverridden   = "boot-overridden"
+	stagePoweredOn        = "powered-on"
+	stageOsReachable      = "os-reachable"
+	stageKeyCopied        = "key-copied"
+	stageSshHardened      = "ssh-hardened"
+)
+
+// Last completed stage of Create.
+// This is saved under machine store path to resume provisioning after process restart.
+type Checkpoint struct {
+	Path               string        `json:"-"`
+	Stage              string        `json:"stage"`
+	ServerProfileName  string        `json:"serverProfileName"`
+	ServerHardwareName string        `json:"serverHardwareName"`
+	IpAllocation       *IpAllocation `json:"ipAllocation,omitempty"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+}
+
+// Read checkpoint file. Return empty checkpoint if file does not exist.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{
+		Path:  path,
+		Stage: stageNone,
+	}
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, checkpoint); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	log.Debugf("Checkpoint: %#v", checkpoint)
+	return checkpoint, nil
+}
+
+// Record completed stage
+func (c *Checkpoint) Save(stage string) error {
+	c.Stage = stage
+	c.UpdatedAt = time.Now()
+	bytes, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Write to temporary file first not to break checkpoint when process is killed
+	tmpPath := c.Path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, bytes, 0600); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := os.Rename(tmpPath, c.Path); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Debugf("Checkpoint %s is saved to %s", stage, c.Path)
+	return nil
+}
+
+// Delete checkpoint file
+func (c *Checkpoint) Remove() error {
+	c.Stage = stageNone
+	if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
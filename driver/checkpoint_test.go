@@ -0,0 +1,113 @@
+package driver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/machine/libmachine/ssh"
+)
+
+func TestCheckpointHandling(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ov-checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, checkpointFileName)
+
+	checkpoint, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.Stage != stageNone {
+		t.Fatalf("Stage of new checkpoint is %s", checkpoint.Stage)
+	}
+
+	checkpoint.ServerProfileName = "Rancher-test"
+	if err := checkpoint.Save(stagePoweredOn); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err = LoadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.Stage != stagePoweredOn || checkpoint.ServerProfileName != "Rancher-test" {
+		t.Fatalf("Checkpoint is not restored: %#v", checkpoint)
+	}
+
+	if err := checkpoint.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("Checkpoint file is not removed")
+	}
+}
+
+func TestCheckpointResumeKeyLogin(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "ov-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(storePath)
+
+	// Fake HPE OneView does not have server profile template, so Create stops at profile stage
+	s := createTestLabelServer()
+	defer s.server.Close()
+	web := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer web.Close()
+
+	d := NewDriver("node1", storePath)
+	d.HpeConfig = &HpeConfig{
+		KeepOnFailure: true,
+		Oneview:       createTestLabelOneview(s.server.URL, "ov-docker-machine-node1"),
+		Server: &Server{
+			Address:     "192.168.1.10",
+			KsBaseUrl:   web.URL,
+			KsOutputDir: storePath,
+		},
+	}
+	d.HpeConfig.Oneview.ServerHardwareName = "bay1"
+	d.HpeConfig.Server.updateKsUrl()
+	if !d.HpeConfig.Server.isKeyLogin() {
+		t.Fatal("Generated kickstart should use key login")
+	}
+
+	// Kickstart with public key was generated in previous run
+	if err := os.MkdirAll(d.ResolveStorePath("."), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ssh.GenerateSSHKey(d.GetSSHKeyPath()); err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ioutil.ReadFile(d.GetSSHKeyPath() + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := LoadCheckpoint(d.ResolveStorePath(checkpointFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoint.ServerProfileName = "ov-docker-machine-node1"
+	checkpoint.ServerHardwareName = "bay1"
+	if err := checkpoint.Save(stageKickstartCreated); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Create(); err == nil {
+		t.Fatal("Create should fail without server profile template")
+	}
+	if d.HpeConfig.Server.SshKeyPath != d.GetSSHKeyPath() {
+		t.Errorf("Key pair of previous run is not loaded: %q", d.HpeConfig.Server.SshKeyPath)
+	}
+	if d.HpeConfig.Server.SshPublicKey+"\n" != string(publicKey) {
+		t.Errorf("Public key is changed: %s", d.HpeConfig.Server.SshPublicKey)
+	}
+	checkpoint, err = LoadCheckpoint(d.ResolveStorePath(checkpointFileName))
+	if err != nil || checkpoint.Stage != stageHardwareReserved {
+		t.Errorf("Provisioning should resume after %s: %s %v", stageKickstartCreated, checkpoint.Stage, err)
+	}
+}
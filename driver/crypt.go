@@ -0,0 +1,134 @@
+package driver
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+)
+
+const (
+	cryptAlphabet     = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	sha512CryptRounds = 5000
+	sha512CryptSalt   = 16
+)
+
+// Order of digest bytes in SHA-512 crypt encoding
+var sha512CryptOrder = [][3]int{
+	{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4}, {47, 5, 26}, {6, 27, 48},
+	{28, 49, 7}, {50, 8, 29}, {9, 30, 51}, {31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13},
+	{56, 14, 35}, {15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19}, {62, 20, 41},
+}
+
+// Hash password for /etc/shadow with random salt.
+// Password which is already crypt hash is returned as it is.
+func hashPassword(password string) (string, error) {
+	if isPasswordHash(password) {
+		return password, nil
+	}
+	salt := make([]byte, sha512CryptSalt)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	for i := range salt {
+		salt[i] = cryptAlphabet[int(salt[i])%len(cryptAlphabet)]
+	}
+	return sha512Crypt(password, string(salt)), nil
+}
+
+func isPasswordHash(password string) bool {
+	for _, prefix := range []string{"$1$", "$5$", "$6$"} {
+		if strings.HasPrefix(password, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SHA-512 based crypt ($6$) with default rounds
+func sha512Crypt(password, salt string) string {
+	if len(salt) > sha512CryptSalt {
+		salt = salt[:sha512CryptSalt]
+	}
+	p, s := []byte(password), []byte(salt)
+
+	b := sha512.New()
+	b.Write(p)
+	b.Write(s)
+	b.Write(p)
+	digestB := b.Sum(nil)
+
+	a := sha512.New()
+	a.Write(p)
+	a.Write(s)
+	a.Write(repeatBytes(digestB, len(p)))
+	for n := len(p); n > 0; n >>= 1 {
+		if n&1 != 0 {
+			a.Write(digestB)
+		} else {
+			a.Write(p)
+		}
+	}
+	digestA := a.Sum(nil)
+
+	dp := sha512.New()
+	for i := 0; i < len(p); i++ {
+		dp.Write(p)
+	}
+	pBytes := repeatBytes(dp.Sum(nil), len(p))
+
+	ds := sha512.New()
+	for i := 0; i < 16+int(digestA[0]); i++ {
+		ds.Write(s)
+	}
+	sBytes := repeatBytes(ds.Sum(nil), len(s))
+
+	c := digestA
+	for i := 0; i < sha512CryptRounds; i++ {
+		h := sha512.New()
+		if i%2 != 0 {
+			h.Write(pBytes)
+		} else {
+			h.Write(c)
+		}
+		if i%3 != 0 {
+			h.Write(sBytes)
+		}
+		if i%7 != 0 {
+			h.Write(pBytes)
+		}
+		if i%2 != 0 {
+			h.Write(c)
+		} else {
+			h.Write(pBytes)
+		}
+		c = h.Sum(nil)
+	}
+
+	var encoded strings.Builder
+	for _, o := range sha512CryptOrder {
+		encodeCrypt64(&encoded, uint(c[o[0]])<<16|uint(c[o[1]])<<8|uint(c[o[2]]), 4)
+	}
+	encodeCrypt64(&encoded, uint(c[63]), 2)
+	return fmt.Sprintf("$6$%s$%s", salt, encoded.String())
+}
+
+// Repeat digest until length n
+func repeatBytes(digest []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		rest := n - len(out)
+		if rest > len(digest) {
+			rest = len(digest)
+		}
+		out = append(out, digest[:rest]...)
+	}
+	return out
+}
+
+func encodeCrypt64(sb *strings.Builder, v uint, n int) {
+	for i := 0; i < n; i++ {
+		sb.WriteByte(cryptAlphabet[v&0x3f])
+		v >>= 6
+	}
+}
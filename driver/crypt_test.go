@@ -0,0 +1,43 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCryptSha512(t *testing.T) {
+	// Expected values are generated by openssl passwd -6
+	cases := []struct {
+		password string
+		salt     string
+		expected string
+	}{
+		{"Hello world!", "saltstring", "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+		{"password", "abc", "$6$abc$rvqzMBuMVukmply9mZJpW0wJMdDfgUKLDrSNxf9l66h/ytQiKNAdqHSj5YPJpxWJpVjRXibQXRddCl9xYHQnd0"},
+	}
+	for _, c := range cases {
+		if hash := sha512Crypt(c.password, c.salt); hash != c.expected {
+			t.Errorf("sha512Crypt(%q, %q) = %s, expected %s", c.password, c.salt, hash, c.expected)
+		}
+	}
+}
+
+func TestCryptHashPassword(t *testing.T) {
+	hash, err := hashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Split(hash, "$")
+	if len(fields) != 4 || fields[1] != "6" || len(fields[2]) != sha512CryptSalt {
+		t.Fatalf("Unexpected hash: %s", hash)
+	}
+	if sha512Crypt("password", fields[2]) != hash {
+		t.Errorf("Hash does not match password: %s", hash)
+	}
+
+	// Hash in config is used as it is
+	again, err := hashPassword(hash)
+	if err != nil || again != hash {
+		t.Errorf("Password hash should not be hashed again: %s %v", again, err)
+	}
+}
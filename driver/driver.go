@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	//	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/machine/libmachine/drivers"
 	log "github.com/docker/machine/libmachine/log"
 	"github.com/docker/machine/libmachine/mcnflag"
+	"github.com/docker/machine/libmachine/mcnutils"
 	"github.com/docker/machine/libmachine/ssh"
 	"github.com/docker/machine/libmachine/state"
 )
@@ -24,6 +27,7 @@ const (
 type Driver struct {
 	*drivers.BaseDriver
 	*HpeConfig
+	httpServer *httpFileServer // Embedded HTTP server running during Create
 }
 
 func NewDriver(hostName, storePath string) *Driver {
@@ -37,71 +41,499 @@ func NewDriver(hostName, storePath string) *Driver {
 	}
 }
 
+// Provisioning stage of Create
+type createStage struct {
+	name   string
+	run    func() error
+	verify func() error // Check completed stage still holds when resuming
+	undo   func() error
+}
+
 // Create a host using the driver's config
-func (d *Driver) Create() error {
+func (d *Driver) Create() (err error) {
 	log.Info("Create server for HPE servers managed by HPE OneView")
 	log.Debugf("BaseDriver: %#v", d.BaseDriver)
 	log.Debugf("HpeConfig: %#v", d.HpeConfig)
 
-	// Create server profile
-	log.Info("Create server profile on HPE OneView")
-	if err := d.HpeConfig.Oneview.CreateServerProfile(); err != nil {
+	// Read last completed stage of previous run
+	checkpoint, err := d.loadCheckpoint()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Resumed stages log in with key pair generated in previous run
+	if checkpoint.Stage != stageNone {
+		if err := d.genSshKeyPairs(); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	// Images are served until installation finishes
+	if err := d.startHttpServer(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer d.stopHttpServer()
+
+	// Undo completed stages when setup fails
+	rb := &rollback{}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if d.HpeConfig.KeepOnFailure {
+			log.Warn("Server setup failed. Failed state is kept for debugging. Please clean up server profile and virtual media manually.")
+			return
+		}
+		log.Warn("Server setup failed. Start to rollback")
+		if err := rb.run(); err != nil {
+			return
+		}
+		checkpoint.Remove()
+	}()
+
+	stages := d.createStages()
+	completed := -1
+	for i, stage := range stages {
+		if stage.name == checkpoint.Stage {
+			completed = i
+		}
+	}
+
+	for i, stage := range stages {
+		if i <= completed {
+			log.Infof("Stage %s was completed in previous run. Check current state", stage.name)
+			if err := stage.verify(); err == nil {
+				if stage.undo != nil {
+					rb.push(stage.name, stage.undo)
+				}
+				continue
+			}
+			log.Warnf("Stage %s does not hold anymore. Resume provisioning from this stage", stage.name)
+			completed = -1
+		}
+
+		if err := stage.run(); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if stage.undo != nil {
+			rb.push(stage.name, stage.undo)
+		}
+		checkpoint.ServerHardwareName = d.HpeConfig.Oneview.ServerHardwareName
+		checkpoint.IpAllocation = d.HpeConfig.Server.Allocation
+		if err := checkpoint.Save(stage.name); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	// Installation media is no longer needed
+	d.ejectInstallMedia()
+	d.HpeConfig.Server.RemoveKickstart()
+
+	if err := checkpoint.Remove(); err != nil {
+		log.Warn(Wrap(err))
+	}
+
+	log.Info("Server setup has been done!")
+	return nil
+}
+
+// Stages of Create in execution order
+func (d *Driver) createStages() []createStage {
+	return []createStage{
+		{
+			name:   stageIpAllocated,
+			run:    d.allocateIp,
+			verify: d.checkIp,
+			undo:   d.releaseIp,
+		},
+		{
+			name: stageKickstartCreated,
+			run:  d.generateKickstart,
+			verify: func() error {
+				if !d.HpeConfig.Server.IsKickstartGenerated() {
+					return nil
+				}
+				return d.HpeConfig.Server.CheckKickstart()
+			},
+			undo: d.HpeConfig.Server.RemoveKickstart,
+		},
+		{
+			name: stageHardwareReserved,
+			run: func() error {
+				log.Info("Reserve server hardware")
+				return d.reserveServerHardware()
+			},
+			verify: func() error {
+				if err := d.HpeConfig.Oneview.CheckServerHardware(); err != nil {
+					return err
+				}
+				return d.reserve(d.HpeConfig.Oneview.ServerHardwareName)
+			},
+			undo: func() error {
+				err := d.releaseServerHardware()
+				if d.HpeConfig.Oneview.ServerHardwarePool.IsEnabled() {
+					d.HpeConfig.Oneview.ServerHardwareName = ""
+				}
+				return err
+			},
+		},
+		{
+			name: stageProfileCreated,
+			run: func() error {
+				log.Info("Create server profile on HPE OneView")
+				return d.HpeConfig.Oneview.CreateServerProfile()
+			},
+			verify: d.HpeConfig.Oneview.CheckServerProfile,
+			undo:   d.HpeConfig.Oneview.DeleteServerProfile,
+		},
+		{
+			name:   stageMediaInserted,
+			run:    d.insertInstallMedia,
+			verify: d.checkInstallMedia,
+			undo:   d.ejectInstallMedia,
+		},
+		{
+			name: stageBootOverridden,
+			run:  d.setOneTimeBoot,
+			verify: func() error {
+				// One-time boot is consumed once server boots
+				powerState, err := d.HpeConfig.Oneview.GetPowerState()
+				if err == nil && powerState == state.Running {
+					return nil
+				}
+				return d.checkOneTimeBoot()
+			},
+		},
+		{
+			name: stagePoweredOn,
+			run: func() error {
+				log.Info("Power on server")
+				return d.HpeConfig.Oneview.PowerOn()
+			},
+			verify: func() error {
+				powerState, err := d.HpeConfig.Oneview.GetPowerState()
+				if err != nil {
+					return err
+				}
+				if powerState != state.Running {
+					return fmt.Errorf("Server is not powered on: %v", powerState)
+				}
+				return nil
+			},
+			undo: d.HpeConfig.Oneview.PowerOff,
+		},
+		{
+			name: stageOsReachable,
+			run: func() error {
+				log.Info("Start OS installation")
+				return d.HpeConfig.Server.WaitOsInstallation()
+			},
+			verify: func() error {
+				return d.HpeConfig.Server.RemoteShell("echo hello", d.HpeConfig.Server.sshPort())
+			},
+		},
+		{
+			name: stageKeyCopied,
+			run: func() error {
+				//Prepare ssh key pair
+				log.Info("Create ssh keys")
+				if err := d.genSshKeyPairs(); err != nil {
+					return err
+				}
+				// Copy public key
+				log.Info("Copy ssh keys")
+				return d.HpeConfig.Server.CopySshPubKey()
+			},
+			verify: func() error {
+				if err := d.genSshKeyPairs(); err != nil {
+					return err
+				}
+				return d.HpeConfig.Server.CheckSshPubKey()
+			},
+		},
+		{
+			name: stageSshHardened,
+			run:  d.hardenSsh,
+			verify: func() error {
+				if !d.HpeConfig.Server.SshHardening {
+					return nil
+				}
+				if !d.HpeConfig.Server.SshHardened {
+					return fmt.Errorf("SSH is not hardened")
+				}
+				return d.HpeConfig.Server.RemoteShell("true", d.HpeConfig.Server.sshPort())
+			},
+		},
+	}
+}
+
+// Harden SSH of installed OS if it is enabled. docker-machine logs in as sudo user from now.
+func (d *Driver) hardenSsh() error {
+	if !d.HpeConfig.Server.SshHardening {
+		return nil
+	}
+	if err := d.HpeConfig.Server.HardenSsh(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	d.BaseDriver.SSHUser = d.HpeConfig.Server.sshUser()
+	d.BaseDriver.SSHPort = d.HpeConfig.Server.sshPort()
+	return nil
+}
+
+func (d *Driver) loadCheckpoint() (*Checkpoint, error) {
+	checkpoint, err := LoadCheckpoint(d.ResolveStorePath(checkpointFileName))
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if checkpoint.Stage == stageNone {
+		return checkpoint, nil
+	}
+
+	// Server hardware selected from pool in previous run is used again
+	if d.HpeConfig.Oneview.ServerHardwarePool.IsEnabled() && d.HpeConfig.Oneview.ServerHardwareName == "" &&
+		checkpoint.ServerProfileName == d.HpeConfig.Oneview.ServerProfileName {
+		log.Infof("Use server hardware %s selected in previous run", checkpoint.ServerHardwareName)
+		d.HpeConfig.Oneview.ServerHardwareName = checkpoint.ServerHardwareName
+	}
+
+	// IP address allocated in previous run is used again
+	if d.isIpAllocated() && d.HpeConfig.Server.Allocation == nil && checkpoint.IpAllocation != nil &&
+		checkpoint.ServerProfileName == d.HpeConfig.Oneview.ServerProfileName {
+		log.Infof("Use IP address %s allocated in previous run", checkpoint.IpAllocation.Address)
+		d.setIpAllocation(checkpoint.IpAllocation)
+	}
+
+	// Checkpoint of other server is not used
+	if checkpoint.ServerProfileName != d.HpeConfig.Oneview.ServerProfileName ||
+		checkpoint.ServerHardwareName != d.HpeConfig.Oneview.ServerHardwareName {
+		log.Warnf("Checkpoint %s is for other server. Start provisioning from beginning", checkpoint.Path)
+		checkpoint.Stage = stageNone
+	} else {
+		log.Infof("Resume provisioning. Last completed stage is %s", checkpoint.Stage)
+	}
+	checkpoint.ServerProfileName = d.HpeConfig.Oneview.ServerProfileName
+	checkpoint.ServerHardwareName = d.HpeConfig.Oneview.ServerHardwareName
+	return checkpoint, nil
+}
+
+// IP address is allocated from HPE OneView IPv4 range or local IPAM file instead of server address
+func (d *Driver) isIpAllocated() bool {
+	return d.HpeConfig.Oneview.IpPool.IsEnabled() || d.HpeConfig.Server.IpamFile != ""
+}
+
+// Get IP address of new server from HPE OneView IPv4 range or local IPAM file
+func (d *Driver) allocateIp() error {
+	var allocation *IpAllocation
+	var err error
+	switch {
+	case d.HpeConfig.Oneview.IpPool.IsEnabled():
+		log.Info("Allocate IP address from HPE OneView")
+		allocation, err = d.HpeConfig.Oneview.AllocateIp()
+	case d.HpeConfig.Server.IpamFile != "":
+		log.Infof("Allocate IP address from %s", d.HpeConfig.Server.IpamFile)
+		allocation, err = AllocateFromIpam(d.HpeConfig.Server.IpamFile, d.GetMachineName())
+	default:
+		return nil
+	}
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	d.setIpAllocation(allocation)
+
+	// Generated kickstart image does not exist yet
+	if d.HpeConfig.Server.IsKickstartGenerated() {
+		return nil
+	}
+	if err := d.HpeConfig.Server.CheckKickstart(); err != nil {
+		log.Error(Wrap(err))
+		d.releaseIp()
+		return err
+	}
+	return nil
+}
+
+// Return allocated IP address to HPE OneView IPv4 range or local IPAM file
+func (d *Driver) releaseIp() error {
+	allocation := d.HpeConfig.Server.Allocation
+	if allocation == nil {
+		return nil
+	}
+	var err error
+	if allocation.IpamFile != "" {
+		err = ReleaseFromIpam(allocation.IpamFile, allocation.Address, d.GetMachineName())
+	} else {
+		err = d.HpeConfig.Oneview.ReleaseIp(allocation)
+	}
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	d.HpeConfig.Server.Allocation = nil
+	return nil
+}
+
+// Check allocated IP address is still kept for this machine
+func (d *Driver) checkIp() error {
+	allocation := d.HpeConfig.Server.Allocation
+	switch {
+	case !d.isIpAllocated():
+		return nil
+	case allocation != nil && allocation.IpamFile != "":
+		return CheckIpamLease(allocation.IpamFile, allocation.Address, d.GetMachineName())
+	default:
+		return d.HpeConfig.Oneview.CheckIp(allocation)
+	}
+}
+
+func (d *Driver) setIpAllocation(allocation *IpAllocation) {
+	d.HpeConfig.Server.SetAllocation(allocation)
+	d.BaseDriver.IPAddress = allocation.Address
+}
+
+// Render kickstart with generated ssh public key and put its image on web server
+func (d *Driver) generateKickstart() error {
+	if !d.HpeConfig.Server.IsKickstartGenerated() {
+		return nil
+	}
+	log.Info("Generate kickstart image")
+	if err := d.genSshKeyPairs(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := d.HpeConfig.Server.GenerateKickstart(); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	if err := d.HpeConfig.Server.CheckKickstart(); err != nil {
+		log.Error(Wrap(err))
+		d.HpeConfig.Server.RemoveKickstart()
+		return err
+	}
+	return nil
+}
 
-	// Create iLO client
+// Mount OS image and kickstart image on iLO virtual media
+func (d *Driver) insertInstallMedia() error {
+	if d.HpeConfig.Server.IsHttpBoot() {
+		return d.setHttpBoot()
+	}
 	iloClient, err := d.HpeConfig.NewIloClient()
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
 
-	// Insert virtual floppy for kickstart
 	log.Info("Mount custom OS image on HPE iLO virtual DVD")
 	if err := iloClient.InsertVirtualMedia(d.HpeConfig.Server.OsUrl, "dvd"); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
-	defer iloClient.EjectVirtualMedia("dvd")
 
 	// Insert virtual floppy for kickstart
 	log.Info("Mount kickstart image on HPE iLO virtual Floppy")
 	if err := iloClient.InsertVirtualMedia(d.HpeConfig.Server.KsUrl, "floppy"); err != nil {
 		log.Error(Wrap(err))
+		iloClient.EjectVirtualMedia("dvd")
 		return err
 	}
-	defer iloClient.EjectVirtualMedia("floppy")
+	return nil
+}
 
-	// Power on to install OS
-	log.Info("Power on server")
-	if err := d.HpeConfig.Oneview.PowerOn(); err != nil {
+// Boot from installation media on next boot regardless of boot order of server profile
+func (d *Driver) setOneTimeBoot() error {
+	target, err := parseBootTarget(d.HpeConfig.Server.bootTarget())
+	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := iloClient.SetOneTimeBoot(target); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
 
-	// Wait OS install
-	log.Info("Start OS installation")
-	d.HpeConfig.Server.WaitOsInstallation()
-
-	//Prepare ssh key pair
-	log.Info("Create ssh keys")
-	if err := d.genSshKeyPairs(); err != nil {
+func (d *Driver) checkOneTimeBoot() error {
+	target, err := parseBootTarget(d.HpeConfig.Server.bootTarget())
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	return iloClient.CheckOneTimeBoot(target)
+}
 
-	// Copy public key
-	log.Info("Copy ssh keys")
-	if err := d.HpeConfig.Server.CopySshPubKey(); err != nil {
+func (d *Driver) checkInstallMedia() error {
+	if d.HpeConfig.Server.IsHttpBoot() {
+		return d.checkHttpBoot()
+	}
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
 
-	log.Info("Server setup has been done!")
+	media := map[string]string{
+		"dvd":    d.HpeConfig.Server.OsUrl,
+		"floppy": d.HpeConfig.Server.KsUrl,
+	}
+	for deviceType, imageUrl := range media {
+		inserted, err := iloClient.IsVirtualMediaInserted(imageUrl, deviceType)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if !inserted {
+			err := fmt.Errorf("%s is not inserted into virtual %s", imageUrl, deviceType)
+			log.Error(Wrap(err))
+			return err
+		}
+	}
 	return nil
 }
 
+// Eject OS image and kickstart image from iLO virtual media
+func (d *Driver) ejectInstallMedia() error {
+	if d.HpeConfig.Server.IsHttpBoot() {
+		return d.clearHttpBoot()
+	}
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	errDvd := iloClient.EjectVirtualMedia("dvd")
+	if errDvd != nil {
+		log.Warn(Wrap(errDvd))
+	}
+	errFloppy := iloClient.EjectVirtualMedia("floppy")
+	if errFloppy != nil {
+		log.Warn(Wrap(errFloppy))
+	}
+	if errDvd != nil {
+		return errDvd
+	}
+	return errFloppy
+}
+
 // DriverName returns the name of the driver
 func (d *Driver) DriverName() string {
 	return driverName
@@ -160,6 +592,7 @@ func (d *Driver) genSshKeyPairs() error {
 	log.Infof("Ssh public key: %s", sshPublicKey)
 	d.HpeConfig.Server.SshPrivateKey = string(sshPrivateKey)
 	d.HpeConfig.Server.SshPublicKey = strings.TrimSuffix(string(sshPublicKey), "\n")
+	d.HpeConfig.Server.SshKeyPath = sshPrivateKeyPath
 
 	return nil
 }
@@ -193,16 +626,38 @@ func (d *Driver) GetURL() (string, error) {
 
 // GetState returns the state that the host is in (running, stopped, etc)
 func (d *Driver) GetState() (state.State, error) {
-	powerState, err := d.HpeConfig.Oneview.GetPowerState()
+	serverState, err := d.HpeConfig.Oneview.GetState()
 	if err != nil {
 		log.Error(Wrap(err))
 		return state.Error, err
 	}
-	return powerState, nil
+	return serverState, nil
 }
 
 // PreCreateCheck allows for pre-create operations to make sure a driver is ready for creation
 func (d *Driver) PreCreateCheck() error {
+	if d.HpeConfig.Oneview.IpPool.IsEnabled() && d.HpeConfig.Server.IpamFile != "" {
+		err := fmt.Errorf("IP pool and IPAM file can not be used at the same time")
+		log.Error(Wrap(err))
+		return err
+	}
+	if d.isIpAllocated() && d.HpeConfig.Server.Address != "" {
+		err := fmt.Errorf("Server address can not be used with IP pool or IPAM file")
+		log.Error(Wrap(err))
+		return err
+	}
+	if !d.isIpAllocated() && d.HpeConfig.Server.Address == "" {
+		err := fmt.Errorf("Server address, IP pool or IPAM file is required")
+		log.Error(Wrap(err))
+		return err
+	}
+	if d.HpeConfig.Server.IpamFile != "" {
+		if _, err := LoadIpamFile(d.HpeConfig.Server.IpamFile); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
 	log.Info("Check HPE OneView configurations")
 	err := d.HpeConfig.Oneview.Validate()
 	if err != nil {
@@ -227,6 +682,23 @@ func (d *Driver) Remove() error {
 		log.Error(Wrap(err))
 		return err
 	}
+	if err := d.releaseServerHardware(); err != nil {
+		log.Warn(Wrap(err))
+	}
+	if err := d.HpeConfig.Server.RemoveKickstart(); err != nil {
+		log.Warn(Wrap(err))
+	}
+	if err := d.releaseIp(); err != nil {
+		log.Warn(Wrap(err))
+	}
+	// Other machines keep using cached session until it expires
+	if d.isSessionShared() {
+		log.Debug("Skip logout from HPE OneView because session is shared with other machines")
+		return nil
+	}
+	if err := d.HpeConfig.Oneview.Logout(); err != nil {
+		log.Warn(Wrap(err))
+	}
 	return nil
 }
 
@@ -262,29 +734,82 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 		d.HpeConfig = &HpeConfig{
 			Oneview: &Oneview{
 				Endpoint:                  flags.String(driverName + "-oneview-endpoint"),
-				ApiVersion:                flags.Int(driverName + "-oneview-api-version"),
+				ApiVersionSetting:         flags.String(driverName + "-oneview-api-version"),
 				Username:                  flags.String(driverName + "-oneview-user"),
 				Password:                  flags.String(driverName + "-oneview-password"),
 				Domain:                    flags.String(driverName + "-oneview-domain"),
 				ServerProfileTemplateName: flags.String(driverName + "-oneview-server-profile-template"),
 				ServerHardwareName:        flags.String(driverName + "-oneview-server-hardware"),
+				TaskTimeout:               flags.Int(driverName + "-oneview-task-timeout"),
+				PowerTimeout:              flags.Int(driverName + "-oneview-power-timeout"),
+				ResetType:                 flags.String(driverName + "-oneview-reset-type"),
+				ProfileOverrides: NewProfileOverridesFromFlags(
+					flags.StringSlice(driverName+"-oneview-profile-connection"),
+					flags.StringSlice(driverName+"-oneview-profile-logical-drive"),
+					flags.StringSlice(driverName+"-oneview-profile-bios"),
+					flags.String(driverName+"-oneview-profile-boot-mode"),
+					flags.StringSlice(driverName+"-oneview-profile-boot-order"),
+				),
+				IpPool: &IpPool{
+					Network: flags.String(driverName + "-oneview-ip-pool-network"),
+					Range:   flags.String(driverName + "-oneview-ip-pool-range"),
+				},
+				ServerHardwarePool: &ServerHardwarePool{
+					Enabled:   flags.Bool(driverName + "-oneview-server-hardware-pool"),
+					Labels:    flags.StringSlice(driverName + "-oneview-server-hardware-pool-label"),
+					Enclosure: flags.String(driverName + "-oneview-server-hardware-pool-enclosure"),
+					Scope:     flags.String(driverName + "-oneview-server-hardware-pool-scope"),
+				},
+				Tls: &TlsConfig{
+					CaCert:      flags.String(driverName + "-tls-ca-cert"),
+					Fingerprint: flags.String(driverName + "-tls-oneview-fingerprint"),
+					Tofu:        flags.Bool(driverName + "-tls-tofu"),
+					Insecure:    flags.Bool(driverName + "-tls-insecure"),
+				},
 			},
 			Server: &Server{
-				Address:      flags.String(driverName + "-server-address"),
-				RootPassword: flags.String(driverName + "-server-root-password"),
-				KsBaseUrl:    flags.String(driverName + "-server-kickstart-base-url"),
-				KsUrl:        fmt.Sprintf("%s/%s.iso", flags.String(driverName+"-server-kickstart-base-url"), flags.String(driverName+"-server-address")),
-				OsUrl:        flags.String(driverName + "-server-os-url"),
+				Address:          flags.String(driverName + "-server-address"),
+				RootPassword:     flags.String(driverName + "-server-root-password"),
+				PasswordLogin:    flags.Bool(driverName + "-server-password-login"),
+				KsBaseUrl:        flags.String(driverName + "-server-kickstart-base-url"),
+				OsUrl:            flags.String(driverName + "-server-os-url"),
+				ShutdownTimeout:  flags.Int(driverName + "-server-shutdown-timeout"),
+				IpamFile:         flags.String(driverName + "-server-ipam-file"),
+				Netmask:          flags.String(driverName + "-server-netmask"),
+				Gateway:          flags.String(driverName + "-server-gateway"),
+				DnsServers:       flags.StringSlice(driverName + "-server-dns"),
+				Domain:           flags.String(driverName + "-server-domain"),
+				KsOutputDir:      flags.String(driverName + "-server-kickstart-output-dir"),
+				KsTemplate:       flags.String(driverName + "-server-kickstart-template"),
+				KsLabel:          flags.String(driverName + "-server-kickstart-label"),
+				KsFormat:         flags.String(driverName + "-server-kickstart-format"),
+				HttpAddress:      flags.String(driverName + "-server-http-address"),
+				OsImage:          flags.String(driverName + "-server-os-image"),
+				InstallMethod:    flags.String(driverName + "-server-install-method"),
+				BootTarget:       flags.String(driverName + "-server-boot-target"),
+				InstallTransport: flags.String(driverName + "-server-install-transport"),
+				HttpBootUrl:      flags.String(driverName + "-server-http-boot-url"),
+				SshHardening:     flags.Bool(driverName + "-server-ssh-hardening"),
+				SshUser:          flags.String(driverName + "-server-ssh-user"),
+				SshPort:          flags.Int(driverName + "-server-ssh-port"),
 			},
 		}
 	}
 
+	if flags.Bool(driverName + "-keep-on-failure") {
+		d.HpeConfig.KeepOnFailure = true
+	}
+
 	d.BaseDriver.IPAddress = d.HpeConfig.Server.Address
-	d.BaseDriver.SSHUser = defaultSshUser
-	d.BaseDriver.SSHPort = defaultSshPort
+	d.BaseDriver.SSHUser = d.HpeConfig.Server.sshUser()
+	d.BaseDriver.SSHPort = d.HpeConfig.Server.sshPort()
 	d.HpeConfig.Server.Hostname = d.GetMachineName()
+	if d.StorePath != "" {
+		d.HpeConfig.Oneview.SessionCacheDir = filepath.Join(d.StorePath, sessionDirName)
+	}
 	d.HpeConfig.Oneview.ServerProfileName = fmt.Sprintf("%s-docker-machine-%s", driverName, d.GetMachineName())
-	d.HpeConfig.Server.KsUrl = fmt.Sprintf("%s/%s.iso", d.HpeConfig.Server.KsBaseUrl, d.HpeConfig.Server.Address)
+	d.HpeConfig.Server.updateKsUrl()
+	d.HpeConfig.Server.useHttpServer(d.ResolveStorePath("."))
 
 	log.Debugf("BaseDriver: %#v", d.BaseDriver)
 	log.Debugf("HpeConfig: %#v", d.HpeConfig)
@@ -301,25 +826,66 @@ func (d *Driver) Start() error {
 	return nil
 }
 
-// Stop a host gracefully
+// Stop a host gracefully.
+// OS is shut down via ssh first and power button is pressed when it does not power off in time.
 func (d *Driver) Stop() error {
-	if err := d.HpeConfig.Oneview.PowerOff(); err != nil {
+	powerState, err := d.HpeConfig.Oneview.GetPowerState()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if powerState == state.Stopped {
+		log.Infof("Server is already powered off")
+		return nil
+	}
+
+	err = d.shutdownOs()
+	if err == nil {
+		log.Info("Server has been stopped by OS shutdown via ssh")
+		return nil
+	}
+	log.Warnf("OS shutdown via ssh did not power off server: %v", err)
+
+	log.Info("Press power button to stop server")
+	if err := d.HpeConfig.Oneview.ShutDown(); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	log.Info("Server has been stopped by power button")
 	return nil
 }
 
-// Restart a host. This may just call Stop(); Start() if the provider does not
-// have any special restart behaviour.
-func (d *Driver) Restart() error {
-	err := d.Stop()
-	if err != nil {
-		log.Error(Wrap(err))
+// Run shutdown command with generated ssh key and wait for power off
+func (d *Driver) shutdownOs() error {
+	// Command returns before ssh connection is closed by shutdown
+	command := "nohup sh -c 'sleep 1; shutdown -h now' > /dev/null 2>&1 &"
+	if d.GetSSHUsername() != defaultSshUser {
+		command = "sudo " + command
+	}
+	log.Infof("Shut down OS via ssh")
+	if _, err := drivers.RunSSHCommandFromDriver(d, command); err != nil {
 		return err
 	}
-	err = d.Start()
-	if err != nil {
+
+	timeout := d.HpeConfig.Server.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	log.Infof("Waiting for server to be powered off... Timeout is %v sec", timeout)
+	attempts := int(time.Duration(timeout) * time.Second / powerStateInterval)
+	stopped := func() bool {
+		powerState, err := d.HpeConfig.Oneview.GetPowerState()
+		return err == nil && powerState == state.Stopped
+	}
+	if err := mcnutils.WaitForSpecific(stopped, attempts, powerStateInterval); err != nil {
+		return fmt.Errorf("Server was not powered off in %d sec", timeout)
+	}
+	return nil
+}
+
+// Restart a host with reset of server hardware
+func (d *Driver) Restart() error {
+	if err := d.HpeConfig.Oneview.Reset(); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
@@ -328,8 +894,7 @@ func (d *Driver) Restart() error {
 
 // Kill stops a host forcefully
 func (d *Driver) Kill() error {
-	err := d.Stop()
-	if err != nil {
+	if err := d.HpeConfig.Oneview.PowerOff(); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
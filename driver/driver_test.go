@@ -14,7 +14,7 @@ const (
 	//	driverTestDefaultHostname                         = "bay4"
 	driverTestDefaultStorePath                        = "/tmp"
 	driverTestDefaultOneviewEndpoint                  = "https://192.168.2.6"
-	driverTestDefaultOneviewApiVersion                = 1200
+	driverTestDefaultOneviewApiVersion                = "1200"
 	driverTestDefaultOneviewUsername                  = "rancher"
 	driverTestDefaultOneviewPassword                  = "password"
 	driverTestDefaultOneviewServerProfileTemplateName = "Rancher-template"
@@ -0,0 +1,175 @@
+package driver
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 1.44MB floppy
+const (
+	fatSectorSize     = 512
+	fatTotalSectors   = 2880
+	fatSectorsPerFat  = 9
+	fatRootEntries    = 224
+	fatReservedSector = 1
+	fatNumFats        = 2
+	fatMaxLabel       = 11
+	fatMediaType      = 0xf0
+)
+
+var fatNameChars = regexp.MustCompile(`^[A-Za-z0-9_~!#$%&'()@^{}-]+$`)
+
+// FAT12 floppy image which has files only in root directory
+type fatImage struct {
+	label string
+	files []fatFile
+}
+
+type fatFile struct {
+	name  [11]byte
+	lower byte // Case flags of base name and extension
+	data  []byte
+}
+
+func newFatImage(label string) *fatImage {
+	return &fatImage{label: label}
+}
+
+// Add file with 8.3 name in root directory
+func (img *fatImage) AddBytes(path string, data []byte) error {
+	name := strings.Trim(path, "/")
+	base, ext := name, ""
+	if i := strings.LastIndex(name, "."); i > 0 {
+		base, ext = name[:i], name[i+1:]
+	}
+	if len(base) > 8 || len(ext) > 3 || !fatNameChars.MatchString(base) || (ext != "" && !fatNameChars.MatchString(ext)) {
+		return fmt.Errorf("%s is not 8.3 file name for FAT image", path)
+	}
+	f := fatFile{data: data}
+	copy(f.name[:], padString(strings.ToUpper(base), 8))
+	copy(f.name[8:], padString(strings.ToUpper(ext), 3))
+	// Linux and Windows show lower case name by these flags
+	if base == strings.ToLower(base) {
+		f.lower |= 0x08
+	}
+	if ext == strings.ToLower(ext) {
+		f.lower |= 0x10
+	}
+	for _, other := range img.files {
+		if other.name == f.name {
+			return fmt.Errorf("%s already exists in FAT image", path)
+		}
+	}
+	if len(img.files)+1 >= fatRootEntries {
+		return fmt.Errorf("Too many files for FAT image")
+	}
+	img.files = append(img.files, f)
+	return nil
+}
+
+// Write whole image
+func (img *fatImage) WriteTo(w io.Writer) (int64, error) {
+	if len(img.label) > fatMaxLabel {
+		return 0, fmt.Errorf("Volume label %s is longer than %d characters", img.label, fatMaxLabel)
+	}
+	rootSectors := fatRootEntries * 32 / fatSectorSize
+	dataStart := fatReservedSector + fatNumFats*fatSectorsPerFat + rootSectors
+	disk := make([]byte, fatTotalSectors*fatSectorSize)
+
+	// Boot sector
+	boot := disk[:fatSectorSize]
+	copy(boot[0:3], []byte{0xeb, 0x3c, 0x90})
+	copy(boot[3:11], "MSDOS5.0")
+	binary.LittleEndian.PutUint16(boot[11:13], fatSectorSize)
+	boot[13] = 1
+	binary.LittleEndian.PutUint16(boot[14:16], fatReservedSector)
+	boot[16] = fatNumFats
+	binary.LittleEndian.PutUint16(boot[17:19], fatRootEntries)
+	binary.LittleEndian.PutUint16(boot[19:21], fatTotalSectors)
+	boot[21] = fatMediaType
+	binary.LittleEndian.PutUint16(boot[22:24], fatSectorsPerFat)
+	binary.LittleEndian.PutUint16(boot[24:26], 18)
+	binary.LittleEndian.PutUint16(boot[26:28], 2)
+	boot[38] = 0x29
+	rand.Read(boot[39:43])
+	copy(boot[43:54], padString(img.label, fatMaxLabel))
+	copy(boot[54:62], "FAT12   ")
+	boot[510], boot[511] = 0x55, 0xaa
+
+	fat := make([]byte, fatSectorsPerFat*fatSectorSize)
+	setFat12(fat, 0, 0xf00|fatMediaType)
+	setFat12(fat, 1, 0xfff)
+
+	root := disk[(fatReservedSector+fatNumFats*fatSectorsPerFat)*fatSectorSize:]
+	date, clock := fatTime(time.Now())
+	entry := 0
+	if img.label != "" {
+		e := root[:32]
+		copy(e[0:11], padString(img.label, fatMaxLabel))
+		e[11] = 0x08
+		binary.LittleEndian.PutUint16(e[22:24], clock)
+		binary.LittleEndian.PutUint16(e[24:26], date)
+		entry++
+	}
+
+	cluster := 2
+	maxCluster := fatTotalSectors - dataStart + 1
+	for _, f := range img.files {
+		clusters := (len(f.data) + fatSectorSize - 1) / fatSectorSize
+		if cluster+clusters-1 > maxCluster {
+			return 0, fmt.Errorf("Files are too large for FAT floppy image")
+		}
+		e := root[entry*32 : entry*32+32]
+		copy(e[0:11], f.name[:])
+		e[11] = 0x20
+		e[12] = f.lower
+		binary.LittleEndian.PutUint16(e[14:16], clock)
+		binary.LittleEndian.PutUint16(e[16:18], date)
+		binary.LittleEndian.PutUint16(e[18:20], date)
+		binary.LittleEndian.PutUint16(e[22:24], clock)
+		binary.LittleEndian.PutUint16(e[24:26], date)
+		if clusters > 0 {
+			binary.LittleEndian.PutUint16(e[26:28], uint16(cluster))
+		}
+		binary.LittleEndian.PutUint32(e[28:32], uint32(len(f.data)))
+		entry++
+
+		copy(disk[(dataStart+cluster-2)*fatSectorSize:], f.data)
+		for i := 0; i < clusters; i++ {
+			next := cluster + i + 1
+			if i == clusters-1 {
+				next = 0xfff
+			}
+			setFat12(fat, cluster+i, next)
+		}
+		cluster += clusters
+	}
+
+	for i := 0; i < fatNumFats; i++ {
+		copy(disk[(fatReservedSector+i*fatSectorsPerFat)*fatSectorSize:], fat)
+	}
+	n, err := w.Write(disk)
+	return int64(n), err
+}
+
+func setFat12(fat []byte, n, value int) {
+	offset := n * 3 / 2
+	if n%2 == 0 {
+		fat[offset] = byte(value)
+		fat[offset+1] = fat[offset+1]&0xf0 | byte(value>>8)&0x0f
+	} else {
+		fat[offset] = fat[offset]&0x0f | byte(value<<4)
+		fat[offset+1] = byte(value >> 4)
+	}
+}
+
+func fatTime(t time.Time) (uint16, uint16) {
+	date := uint16((t.Year()-1980)<<9 | int(t.Month())<<5 | t.Day())
+	clock := uint16(t.Hour()<<11 | t.Minute()<<5 | t.Second()/2)
+	return date, clock
+}
@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+// Returned by tryLockFile when other process holds lock
+var errFileLocked = errors.New("file is locked by other process")
+
+// Advisory lock on open file.
+// OS releases it when process exits, so lock of killed process never goes stale.
+// Lock file is not removed on unlock because other process may have opened it already.
+type fileLock struct {
+	file *os.File
+}
+
+// Lock file exclusively. Lock is retried at interval until timeout.
+func lockFile(path string, timeout, interval time.Duration) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := tryLockFile(f)
+		if err == nil {
+			log.Debugf("Lock file %s is locked", path)
+			return &fileLock{file: f}, nil
+		}
+		if err != errFileLocked {
+			f.Close()
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("Could not lock %s in %v", path, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+func (l *fileLock) Unlock() error {
+	defer l.file.Close()
+	return unlockFile(l.file)
+}
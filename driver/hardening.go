@@ -0,0 +1,152 @@
+package driver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	defaultHardenedUser  = "docker-machine"
+	hardeningConfName    = "00-docker-machine-ov.conf"
+	randomPasswordLength = 24
+)
+
+var linuxUserName = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// Script run on installed OS. Values are formatted as user, public key, root password hash and port.
+// First value of sshd options is used, so options are put on top of sshd_config or in first drop-in file.
+const hardeningScript = `set -e
+id %[1]s > /dev/null 2>&1 || useradd -m %[1]s
+home=$(getent passwd %[1]s | cut -d: -f6)
+mkdir -p -m 0700 "$home/.ssh"
+echo '%[2]s docker-machine-ov' > "$home/.ssh/authorized_keys"
+chmod 0600 "$home/.ssh/authorized_keys"
+chown -R %[1]s: "$home/.ssh"
+echo '%[1]s ALL=(ALL) NOPASSWD:ALL' > /etc/sudoers.d/docker-machine-ov
+chmod 0440 /etc/sudoers.d/docker-machine-ov
+usermod -p '%[3]s' root
+
+options='Port %[4]d
+PasswordAuthentication no
+ChallengeResponseAuthentication no
+PermitRootLogin no'
+if grep -qi '^Include /etc/ssh/sshd_config.d/' /etc/ssh/sshd_config; then
+  echo "$options" > /etc/ssh/sshd_config.d/` + hardeningConfName + `
+else
+  { echo "$options"; grep -v -i -E '^[[:space:]]*#?[[:space:]]*(Port|PasswordAuthentication|ChallengeResponseAuthentication|PermitRootLogin)[[:space:]]' /etc/ssh/sshd_config; } > /etc/ssh/sshd_config.new
+  mv /etc/ssh/sshd_config.new /etc/ssh/sshd_config
+fi
+$(command -v sshd || echo /usr/sbin/sshd) -t
+
+if [ %[4]d -ne 22 ] && command -v selinuxenabled > /dev/null && selinuxenabled && command -v semanage > /dev/null; then
+  semanage port -a -t ssh_port_t -p tcp %[4]d || semanage port -m -t ssh_port_t -p tcp %[4]d
+fi
+if command -v firewall-cmd > /dev/null && firewall-cmd --state > /dev/null 2>&1; then
+  firewall-cmd --permanent --add-port=%[4]d/tcp && firewall-cmd --reload
+fi
+
+# sshd of Flatcar is started by socket which does not read port in sshd_config
+if systemctl is-active -q sshd.socket; then
+  mkdir -p /etc/systemd/system/sshd.socket.d
+  printf '[Socket]\nListenStream=\nListenStream=%[4]d\n' > /etc/systemd/system/sshd.socket.d/` + hardeningConfName + `
+  systemctl daemon-reload
+  systemctl restart sshd.socket
+elif systemctl is-active -q ssh.socket; then
+  systemctl daemon-reload
+  systemctl restart ssh.socket
+else
+  systemctl restart sshd 2> /dev/null || systemctl restart ssh
+fi
+`
+
+// User which driver logs in as after hardening
+func (s *Server) hardenedUser() string {
+	if s.SshUser == "" {
+		return defaultHardenedUser
+	}
+	return s.SshUser
+}
+
+// Port of sshd on installed OS
+func (s *Server) sshPort() int {
+	if s.SshHardened && s.SshPort != 0 {
+		return s.SshPort
+	}
+	return defaultSshPort
+}
+
+func (s *Server) validateSshHardening() error {
+	if !s.SshHardening {
+		return nil
+	}
+	user := s.hardenedUser()
+	if !linuxUserName.MatchString(user) || user == defaultSshUser {
+		return fmt.Errorf("SSH user %s is not valid non-root user name", user)
+	}
+	if s.SshPort < 0 || s.SshPort > 65535 {
+		return fmt.Errorf("SSH port %d is out of range", s.SshPort)
+	}
+	return nil
+}
+
+// Command which runs hardening script as root
+func (s *Server) hardeningShell(rootPasswordHash string) string {
+	port := s.SshPort
+	if port == 0 {
+		port = defaultSshPort
+	}
+	script := fmt.Sprintf(hardeningScript, s.hardenedUser(), s.SshPublicKey, rootPasswordHash, port)
+	shell := fmt.Sprintf("sh <<'DOCKER_MACHINE_OV'\n%s\nDOCKER_MACHINE_OV", script)
+	if s.sshUser() != defaultSshUser {
+		shell = "sudo " + shell
+	}
+	return shell
+}
+
+// Disable password login, set random root password and create sudo user for docker-machine.
+// Random root password is not saved anywhere.
+func (s *Server) HardenSsh() error {
+	if s.SshPublicKey == "" {
+		err := fmt.Errorf("Public key is not generated")
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Previous run may have completed hardening before checkpoint was saved
+	s.SshHardened = true
+	if err := s.RemoteShell("true", s.sshPort()); err == nil {
+		log.Infof("SSH is already hardened. Log in as %s on port %d", s.sshUser(), s.sshPort())
+		return nil
+	}
+	s.SshHardened = false
+
+	password := make([]byte, randomPasswordLength)
+	if _, err := rand.Read(password); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	hash, err := hashPassword(base64.RawStdEncoding.EncodeToString(password))
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	shell := s.hardeningShell(hash)
+	log.Infof("Harden SSH and create user %s", s.hardenedUser())
+	if err := s.RemoteShell(shell, s.sshPort()); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Driver uses new user and port from now
+	s.SshHardened = true
+	if err := s.RemoteShell("true", s.sshPort()); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Infof("SSH is hardened. Log in as %s on port %d", s.sshUser(), s.sshPort())
+	return nil
+}
@@ -0,0 +1,70 @@
+package driver
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestHardeningShell(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	defer cleanup()
+	s.SshHardening = true
+	s.SshPort = 2222
+
+	shell := s.hardeningShell("$6$salt$hash")
+	for _, expected := range []string{
+		"useradd -m docker-machine",
+		"echo 'ssh-rsa AAAAB3NzaC1yc2E node1 docker-machine-ov' >",
+		"echo 'docker-machine ALL=(ALL) NOPASSWD:ALL' > /etc/sudoers.d/docker-machine-ov",
+		"usermod -p '$6$salt$hash' root",
+		"options='Port 2222\nPasswordAuthentication no\n",
+	} {
+		if !strings.Contains(shell, expected) {
+			t.Errorf("Hardening shell does not contain %q:\n%s", expected, shell)
+		}
+	}
+	if strings.HasPrefix(shell, "sudo ") {
+		t.Error("Hardening shell should not use sudo as root")
+	}
+	if sh, err := exec.LookPath("sh"); err == nil {
+		if out, err := exec.Command(sh, "-n", "-c", shell).CombinedOutput(); err != nil {
+			t.Errorf("Hardening shell has syntax error: %v %s", err, out)
+		}
+	}
+
+	// Ignition OS is hardened with sudo of core user
+	s.InstallMethod = installMethodIgnition
+	if !strings.HasPrefix(s.hardeningShell("$6$salt$hash"), "sudo sh <<'DOCKER_MACHINE_OV'\n") {
+		t.Error("Hardening shell should use sudo as core")
+	}
+}
+
+func TestHardeningLogin(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	defer cleanup()
+	s.SshHardening = true
+	s.SshPort = 2222
+	s.PasswordLogin = true
+
+	if s.sshUser() != defaultSshUser || s.sshPort() != defaultSshPort || s.isKeyLogin() {
+		t.Errorf("Unexpected login before hardening: %s %d %v", s.sshUser(), s.sshPort(), s.isKeyLogin())
+	}
+	s.SshHardened = true
+	if s.sshUser() != defaultHardenedUser || s.sshPort() != 2222 || !s.isKeyLogin() {
+		t.Errorf("Unexpected login after hardening: %s %d %v", s.sshUser(), s.sshPort(), s.isKeyLogin())
+	}
+
+	for _, invalid := range []*Server{
+		{SshHardening: true, SshUser: "root"},
+		{SshHardening: true, SshUser: "Docker Machine"},
+		{SshHardening: true, SshPort: 70000},
+	} {
+		if err := invalid.validateSshHardening(); err == nil {
+			t.Errorf("Invalid hardening should be error: %+v", invalid)
+		}
+	}
+	if err := (&Server{SshUser: "root"}).validateSshHardening(); err != nil {
+		t.Errorf("SSH user should not be checked without hardening: %v", err)
+	}
+}
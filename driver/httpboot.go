@@ -0,0 +1,270 @@
+package driver
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/docker/machine/libmachine/log"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const (
+	installTransportVirtualMedia = "virtual-media"
+	installTransportHttpBoot     = "http-boot"
+	biosUrlBootFile              = "UrlBootFile"
+	httpBootMenuTimeout          = 1 //sec
+)
+
+// Kickstart file itself which installer reads from URL on kernel command line
+type plainKickstart struct {
+	data []byte
+}
+
+func (p *plainKickstart) AddBytes(path string, data []byte) error {
+	if p.data != nil {
+		return fmt.Errorf("Only one file can be written as plain kickstart: %s", path)
+	}
+	p.data = data
+	return nil
+}
+
+func (p *plainKickstart) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(p.data)
+	return int64(n), err
+}
+
+// Server boots installer by UEFI HTTP boot instead of virtual media
+func (s *Server) IsHttpBoot() bool {
+	return strings.ToLower(s.InstallTransport) == installTransportHttpBoot
+}
+
+// Remastered OS image which has kickstart URL on kernel command line
+func (s *Server) httpBootImageName() string {
+	return s.Address + "-boot.iso"
+}
+
+// Boot image is generated from local OS image unless URL is specified
+func (s *Server) isHttpBootImageGenerated() bool {
+	return s.IsHttpBoot() && s.HttpBootUrl == "" && s.OsImage != "" && s.IsHttpServerEnabled()
+}
+
+// URL which is set in UrlBootFile of BIOS
+func (s *Server) httpBootUrl() string {
+	if s.HttpBootUrl != "" {
+		return s.HttpBootUrl
+	}
+	if s.isHttpBootImageGenerated() {
+		return fmt.Sprintf("http://%s/%s", s.httpUrlAddress(), s.httpBootImageName())
+	}
+	return ""
+}
+
+// UEFI HTTP boot is default boot target of http-boot
+func (s *Server) bootTarget() string {
+	switch {
+	case s.BootTarget != "":
+		return s.BootTarget
+	case s.IsHttpBoot():
+		return string(redfish.UefiHTTPBootSourceOverrideTarget)
+	default:
+		return defaultBootTarget
+	}
+}
+
+func (s *Server) validateInstallTransport() error {
+	switch strings.ToLower(s.InstallTransport) {
+	case "", installTransportVirtualMedia:
+		return nil
+	case installTransportHttpBoot:
+	default:
+		return fmt.Errorf("Unknown install transport: %s. Specify %s or %s", s.InstallTransport, installTransportVirtualMedia, installTransportHttpBoot)
+	}
+
+	if s.installMethodName() != installMethodKickstart {
+		return fmt.Errorf("UEFI HTTP boot supports %s only: %s", installMethodKickstart, s.installMethodName())
+	}
+	if !s.IsKickstartGenerated() {
+		return fmt.Errorf("Kickstart URL is written on kernel command line for UEFI HTTP boot. Specify kickstart output directory or HTTP address")
+	}
+	if s.httpBootUrl() == "" {
+		return fmt.Errorf("HTTP boot URL or local OS image served by embedded HTTP server is required for UEFI HTTP boot")
+	}
+	if !strings.EqualFold(s.bootTarget(), string(redfish.UefiHTTPBootSourceOverrideTarget)) {
+		return fmt.Errorf("Boot target should be %s for UEFI HTTP boot: %s", redfish.UefiHTTPBootSourceOverrideTarget, s.bootTarget())
+	}
+	return nil
+}
+
+// Remaster local OS image with kickstart URL.
+// Installer finds stage2 by volume label of image loaded by UEFI HTTP boot.
+func (s *Server) GenerateHttpBootImage() error {
+	if !s.isHttpBootImageGenerated() {
+		return nil
+	}
+	path := filepath.Join(s.KsOutputDir, s.httpBootImageName())
+	opts := &RemasterOptions{
+		KsUrl:   s.KsUrl,
+		Timeout: httpBootMenuTimeout,
+	}
+	log.Infof("Generate UEFI HTTP boot image %s", path)
+	if err := RemasterIso(s.OsImage, path, opts); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Delete generated UEFI HTTP boot image
+func (s *Server) RemoveHttpBootImage() error {
+	if !s.isHttpBootImageGenerated() || s.Address == "" {
+		return nil
+	}
+	path := filepath.Join(s.KsOutputDir, s.httpBootImageName())
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Set UEFI HTTP boot URL instead of inserting virtual media
+func (d *Driver) setHttpBoot() error {
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	bootUrl := d.HpeConfig.Server.httpBootUrl()
+	log.Infof("Set UEFI HTTP boot URL %s", bootUrl)
+	if err := iloClient.SetUrlBootFile(bootUrl); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+func (d *Driver) checkHttpBoot() error {
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	bootUrl, err := iloClient.GetUrlBootFile()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if bootUrl != d.HpeConfig.Server.httpBootUrl() {
+		err := fmt.Errorf("UEFI HTTP boot URL is %s, not %s", bootUrl, d.HpeConfig.Server.httpBootUrl())
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Clear UEFI HTTP boot URL not to boot installer again
+func (d *Driver) clearHttpBoot() error {
+	iloClient, err := d.HpeConfig.NewIloClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Info("Clear UEFI HTTP boot URL")
+	if err := iloClient.SetUrlBootFile(""); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// URI of pending BIOS settings. BIOS attributes are checked to support UEFI HTTP boot.
+func (ilo *IloClient) getBiosSettingsUri(c *gofish.APIClient) (string, error) {
+	systems, err := c.Service.Systems()
+	if err != nil {
+		return "", err
+	}
+	if len(systems) == 0 {
+		return "", fmt.Errorf("Could not find computer system on HPE iLO %s", ilo.Address)
+	}
+	var system struct {
+		Bios IloVirtualMediaMember `json:"Bios"`
+	}
+	if err := ilo.getRedfishResource(c, systems[0].ODataID, &system); err != nil {
+		return "", err
+	}
+	if system.Bios.Id == "" {
+		return "", fmt.Errorf("Computer system on HPE iLO %s does not have BIOS resource", ilo.Address)
+	}
+	var bios struct {
+		Attributes map[string]interface{} `json:"Attributes"`
+		Settings   struct {
+			SettingsObject IloVirtualMediaMember `json:"SettingsObject"`
+		} `json:"@Redfish.Settings"`
+	}
+	if err := ilo.getRedfishResource(c, system.Bios.Id, &bios); err != nil {
+		return "", err
+	}
+	if _, ok := bios.Attributes[biosUrlBootFile]; !ok {
+		return "", fmt.Errorf("BIOS of HPE iLO %s does not support UEFI HTTP boot. %s attribute is not found", ilo.Address, biosUrlBootFile)
+	}
+	if bios.Settings.SettingsObject.Id == "" {
+		return system.Bios.Id, nil
+	}
+	return bios.Settings.SettingsObject.Id, nil
+}
+
+// Set URL of UEFI HTTP boot in BIOS. It is applied on next boot.
+func (ilo *IloClient) SetUrlBootFile(bootUrl string) error {
+	c, err := ilo.createRedfishClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer c.Logout()
+
+	uri, err := ilo.getBiosSettingsUri(c)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	req := map[string]interface{}{
+		"Attributes": map[string]string{
+			biosUrlBootFile: bootUrl,
+		},
+	}
+	log.Debugf("Patch BIOS settings %s: %#v", uri, req)
+	if _, err := c.Patch(uri, req); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// URL of UEFI HTTP boot in pending BIOS settings
+func (ilo *IloClient) GetUrlBootFile() (string, error) {
+	c, err := ilo.createRedfishClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return "", err
+	}
+	defer c.Logout()
+
+	uri, err := ilo.getBiosSettingsUri(c)
+	if err != nil {
+		log.Error(Wrap(err))
+		return "", err
+	}
+	var settings struct {
+		Attributes map[string]interface{} `json:"Attributes"`
+	}
+	if err := ilo.getRedfishResource(c, uri, &settings); err != nil {
+		log.Error(Wrap(err))
+		return "", err
+	}
+	bootUrl, _ := settings.Attributes[biosUrlBootFile].(string)
+	return bootUrl, nil
+}
@@ -0,0 +1,129 @@
+package driver
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createTestHttpBootServer(t *testing.T) (*Server, func()) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	s.InstallTransport = installTransportHttpBoot
+	s.HttpAddress = "192.168.1.5:8080"
+	s.OsImage = filepath.Join(s.KsOutputDir, "CentOS-7-x86_64-DVD.iso")
+	createTestInstallerIso(t, s.OsImage)
+	s.useHttpServer(s.KsOutputDir)
+	return s, cleanup
+}
+
+func TestHttpBootKickstart(t *testing.T) {
+	s, cleanup := createTestHttpBootServer(t)
+	defer cleanup()
+
+	if s.KsUrl != "http://192.168.1.5:8080/192.168.1.10-ks.cfg" {
+		t.Errorf("Unexpected kickstart URL: %s", s.KsUrl)
+	}
+	if s.httpBootUrl() != "http://192.168.1.5:8080/192.168.1.10-boot.iso" {
+		t.Errorf("Unexpected HTTP boot URL: %s", s.httpBootUrl())
+	}
+	if err := s.GenerateKickstart(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Kickstart is written as plain file
+	ksPath := filepath.Join(s.KsOutputDir, "192.168.1.10-ks.cfg")
+	ks, err := ioutil.ReadFile(ksPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(ks, []byte("# Generated by docker-machine-driver-ov\n")) {
+		t.Errorf("Kickstart is not plain text:\n%s", ks)
+	}
+	bootPath := filepath.Join(s.KsOutputDir, "192.168.1.10-boot.iso")
+	image, err := ioutil.ReadFile(bootPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(image, []byte("inst.ks=http://192.168.1.5:8080/192.168.1.10-ks.cfg")) {
+		t.Error("Kickstart URL is not written in boot image")
+	}
+	files := s.httpFiles()
+	if files["/192.168.1.10-ks.cfg"] != ksPath || files["/192.168.1.10-boot.iso"] != bootPath {
+		t.Errorf("Unexpected HTTP files: %v", files)
+	}
+
+	if err := s.RemoveKickstart(); err != nil {
+		t.Error(err)
+	}
+	for _, path := range []string{ksPath, bootPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", path)
+		}
+	}
+}
+
+func TestHttpBootValidate(t *testing.T) {
+	s, cleanup := createTestHttpBootServer(t)
+	defer cleanup()
+
+	if err := s.validateInstallTransport(); err != nil {
+		t.Error(err)
+	}
+	if s.bootTarget() != "UefiHttp" {
+		t.Errorf("Default boot target of HTTP boot should be UefiHttp: %s", s.bootTarget())
+	}
+
+	// External boot image or EFI binary is used as it is
+	osImage := s.OsImage
+	s.HttpBootUrl = "http://192.168.1.6/ipxe.efi"
+	if s.httpBootUrl() != s.HttpBootUrl || s.isHttpBootImageGenerated() {
+		t.Errorf("HTTP boot URL should not be generated: %s", s.httpBootUrl())
+	}
+
+	for name, invalid := range map[string]func(s *Server){
+		"transport":   func(s *Server) { s.InstallTransport = "pxe" },
+		"method":      func(s *Server) { s.InstallMethod = installMethodAutoinstall },
+		"boot target": func(s *Server) { s.BootTarget = "Cd" },
+		"boot url":    func(s *Server) { s.HttpBootUrl, s.OsImage = "", "" },
+	} {
+		invalid(s)
+		if err := s.validateInstallTransport(); err == nil {
+			t.Errorf("Invalid %s should be rejected", name)
+		}
+		s.InstallTransport, s.InstallMethod, s.BootTarget = installTransportHttpBoot, "", ""
+		s.HttpBootUrl, s.OsImage = "http://192.168.1.6/ipxe.efi", osImage
+	}
+
+	// Virtual media does not depend on kickstart method
+	s = &Server{InstallMethod: installMethodAutoinstall}
+	if err := s.validateInstallTransport(); err != nil || s.bootTarget() != defaultBootTarget {
+		t.Errorf("Virtual media should be valid: %v %s", err, s.bootTarget())
+	}
+}
+
+func TestHttpBootUrlBootFile(t *testing.T) {
+	ilo, cleanup := createTestRedfishIloClient(t)
+	defer cleanup()
+
+	if err := ilo.SetUrlBootFile("http://192.168.1.5:8080/192.168.1.10-boot.iso"); err != nil {
+		t.Fatal(err)
+	}
+	url, err := ilo.GetUrlBootFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "http://192.168.1.5:8080/192.168.1.10-boot.iso" {
+		t.Errorf("Unexpected UrlBootFile: %s", url)
+	}
+	if err := ilo.SetOneTimeBoot("UefiHttp"); err != nil {
+		t.Error(err)
+	}
+	if err := ilo.SetUrlBootFile(""); err != nil {
+		t.Fatal(err)
+	}
+	if url, _ := ilo.GetUrlBootFile(); url != "" {
+		t.Errorf("UrlBootFile should be cleared: %s", url)
+	}
+}
@@ -0,0 +1,226 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	kickstartDirName         = "kickstart"
+	defaultHttpStopTimeout   = 10 //sec
+	defaultHttpHeaderTimeout = 30 //sec
+)
+
+// HTTP file server embedded in driver.
+// iLO virtual media reads images with Range requests.
+type httpFileServer struct {
+	address  string
+	files    func() map[string]string // URL path to local file path
+	server   *http.Server
+	listener net.Listener
+}
+
+func newHttpFileServer(address string, files func() map[string]string) *httpFileServer {
+	return &httpFileServer{
+		address: address,
+		files:   files,
+	}
+}
+
+// Start listening and serve files in background
+func (h *httpFileServer) Start() error {
+	listener, err := net.Listen("tcp", h.address)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	h.listener = listener
+	server := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: defaultHttpHeaderTimeout * time.Second,
+	}
+	h.server = server
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error(Wrap(err))
+		}
+	}()
+	log.Infof("HTTP server is started on %s", listener.Addr())
+	return nil
+}
+
+// Stop serving. Requests in progress are waited for a while.
+func (h *httpFileServer) Stop() error {
+	if h.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultHttpStopTimeout*time.Second)
+	defer cancel()
+	err := h.server.Shutdown(ctx)
+	if err != nil {
+		h.server.Close()
+	}
+	h.server = nil
+	log.Infof("HTTP server on %s is stopped", h.listener.Addr())
+	return err
+}
+
+// Only registered files are served. Range requests are handled by http.ServeContent.
+func (h *httpFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.Debugf("HTTP %s %s from %s Range: %q", r.Method, r.URL.Path, r.RemoteAddr, r.Header.Get("Range"))
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	path, ok := h.files()[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		log.Debug(Wrap(err))
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// Embedded HTTP server is used instead of external web server
+func (s *Server) IsHttpServerEnabled() bool {
+	return s.HttpAddress != ""
+}
+
+// Point kickstart and OS image URLs to embedded HTTP server.
+// Kickstart image is generated in machine directory unless output directory is specified.
+func (s *Server) useHttpServer(machineDir string) {
+	if !s.IsHttpServerEnabled() {
+		return
+	}
+	if s.KsOutputDir == "" {
+		s.KsOutputDir = filepath.Join(machineDir, kickstartDirName)
+	}
+	s.updateHttpUrls()
+}
+
+// Address in URLs of embedded HTTP server.
+// Port 0 is replaced with port which is assigned when HTTP server starts.
+func (s *Server) httpUrlAddress() string {
+	if s.httpListenAddress != "" {
+		return s.httpListenAddress
+	}
+	return s.HttpAddress
+}
+
+func (s *Server) updateHttpUrls() {
+	s.KsBaseUrl = fmt.Sprintf("http://%s", s.httpUrlAddress())
+	if s.OsImage != "" {
+		s.OsUrl = fmt.Sprintf("http://%s/%s", s.httpUrlAddress(), filepath.Base(s.OsImage))
+	}
+	s.updateKsUrl()
+}
+
+// Files served by embedded HTTP server
+func (s *Server) httpFiles() map[string]string {
+	files := map[string]string{}
+	if s.Address != "" {
+		files["/"+s.kickstartImageName()] = filepath.Join(s.KsOutputDir, s.kickstartImageName())
+	}
+	if s.Address != "" && s.isHttpBootImageGenerated() {
+		files["/"+s.httpBootImageName()] = filepath.Join(s.KsOutputDir, s.httpBootImageName())
+	}
+	if s.OsImage != "" {
+		files["/"+filepath.Base(s.OsImage)] = s.OsImage
+	}
+	return files
+}
+
+// Check embedded HTTP server can listen on address which iLO can reach
+func (s *Server) validateHttpServer() error {
+	host, _, err := net.SplitHostPort(s.HttpAddress)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsUnspecified() {
+		err := fmt.Errorf("HTTP address %s should be IP address which iLO can reach", s.HttpAddress)
+		log.Error(Wrap(err))
+		return err
+	}
+	// Address is not listened here because other creates may be serving on it now
+	if !isLocalIp(ip) {
+		err := fmt.Errorf("HTTP address %s is not address of this host", s.HttpAddress)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	if s.OsImage != "" {
+		info, err := os.Stat(s.OsImage)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if info.IsDir() {
+			err := fmt.Errorf("OS image %s is directory", s.OsImage)
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+	return os.MkdirAll(s.KsOutputDir, 0700)
+}
+
+func isLocalIp(ip net.IP) bool {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		log.Debug(Wrap(err))
+		return false
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Start embedded HTTP server if it is enabled.
+// URLs are updated with listening port, so port 0 lets parallel creates use different ports.
+func (d *Driver) startHttpServer() error {
+	s := d.HpeConfig.Server
+	if !s.IsHttpServerEnabled() {
+		return nil
+	}
+	d.httpServer = newHttpFileServer(s.HttpAddress, s.httpFiles)
+	if err := d.httpServer.Start(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	s.httpListenAddress = d.httpServer.listener.Addr().String()
+	s.updateHttpUrls()
+	return nil
+}
+
+func (d *Driver) stopHttpServer() {
+	if d.httpServer == nil {
+		return
+	}
+	if err := d.httpServer.Stop(); err != nil {
+		log.Warn(Wrap(err))
+	}
+	d.httpServer = nil
+}
@@ -0,0 +1,146 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHttpServerRange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ov-http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	image := filepath.Join(dir, "os.iso")
+	if err := ioutil.WriteFile(image, []byte("0123456789"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := newHttpFileServer("127.0.0.1:0", func() map[string]string {
+		return map[string]string{"/os.iso": image}
+	})
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+	url := fmt.Sprintf("http://%s/os.iso", h.listener.Addr())
+
+	// iLO reads image by Range requests
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Range", "bytes=2-5")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent || string(body) != "2345" || resp.Header.Get("Content-Range") != "bytes 2-5/10" {
+		t.Errorf("Unexpected range response: %d %q %s", resp.StatusCode, body, resp.Header.Get("Content-Range"))
+	}
+
+	resp, err = http.Head(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength != 10 || resp.Header.Get("Accept-Ranges") != "bytes" {
+		t.Errorf("Unexpected HEAD response: %d %d", resp.StatusCode, resp.ContentLength)
+	}
+
+	// Other files in same directory are not served
+	resp, err = http.Get(fmt.Sprintf("http://%s/", h.listener.Addr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Unregistered path should not be served: %d", resp.StatusCode)
+	}
+	resp, err = http.Post(url, "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST should not be allowed: %d", resp.StatusCode)
+	}
+
+	if err := h.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := http.Get(url); err == nil {
+		t.Error("HTTP server should be stopped")
+	}
+}
+
+func TestHttpServerUrls(t *testing.T) {
+	s := &Server{
+		Address:     "192.168.1.10",
+		KsBaseUrl:   "http://web/ks",
+		OsUrl:       "http://web/os.iso",
+		HttpAddress: "192.168.1.5:8080",
+		OsImage:     "/images/rhel8.iso",
+	}
+	s.useHttpServer("/machines/node1")
+	if s.KsUrl != "http://192.168.1.5:8080/192.168.1.10.iso" || s.OsUrl != "http://192.168.1.5:8080/rhel8.iso" {
+		t.Errorf("Unexpected URLs: %s %s", s.KsUrl, s.OsUrl)
+	}
+	if s.KsOutputDir != filepath.Join("/machines/node1", kickstartDirName) || !s.IsKickstartGenerated() {
+		t.Errorf("Kickstart should be generated in machine directory: %s", s.KsOutputDir)
+	}
+	files := s.httpFiles()
+	if files["/192.168.1.10.iso"] != filepath.Join(s.KsOutputDir, "192.168.1.10.iso") || files["/rhel8.iso"] != "/images/rhel8.iso" {
+		t.Errorf("Unexpected files: %v", files)
+	}
+
+	// iLO can not reach unspecified address
+	s.HttpAddress = "0.0.0.0:8080"
+	if err := s.validateHttpServer(); err == nil {
+		t.Error("Unspecified address should be error")
+	}
+}
+
+func TestHttpServerParallel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ov-http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Each create listens on port assigned by OS
+	var drivers []*Driver
+	for _, name := range []string{"node1", "node2"} {
+		d := NewDriver(name, dir)
+		d.HpeConfig = &HpeConfig{Server: &Server{Address: "127.0.0.1", HttpAddress: "127.0.0.1:0"}}
+		d.HpeConfig.Server.useHttpServer(d.ResolveStorePath("."))
+		if err := d.HpeConfig.Server.validateHttpServer(); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.startHttpServer(); err != nil {
+			t.Fatal(err)
+		}
+		defer d.stopHttpServer()
+		drivers = append(drivers, d)
+	}
+	s1, s2 := drivers[0].HpeConfig.Server, drivers[1].HpeConfig.Server
+	if s1.KsUrl == s2.KsUrl {
+		t.Errorf("HTTP servers should listen on different ports: %s", s1.KsUrl)
+	}
+	if s1.KsUrl != fmt.Sprintf("http://%s/127.0.0.1.iso", drivers[0].httpServer.listener.Addr()) {
+		t.Errorf("Kickstart URL is not updated with listening port: %s", s1.KsUrl)
+	}
+
+	// Address in use by other create passes validation
+	s2.HttpAddress = drivers[0].httpServer.listener.Addr().String()
+	if err := s2.validateHttpServer(); err != nil {
+		t.Error(err)
+	}
+	s2.HttpAddress = "192.0.2.1:8080"
+	if err := s2.validateHttpServer(); err == nil {
+		t.Error("Address of other host should be error")
+	}
+}
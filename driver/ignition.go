@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	ignitionLabel    = "ignition"
+	ignitionFileName = "config.ign"
+	ignitionUser     = "core"
+)
+
+// Top level keys of Ignition spec 3
+var ignitionKeys = map[string]bool{
+	"ignition":        true,
+	"passwd":          true,
+	"storage":         true,
+	"systemd":         true,
+	"kernelArguments": true,
+}
+
+// Used when Ignition template is not specified.
+// Network is configured by systemd-networkd on Flatcar and NetworkManager on Fedora CoreOS.
+const defaultIgnitionTemplate = `{{define "networkd"}}[Match]
+Name=en* eth*
+
+[Network]
+Address={{.Address}}/{{.PrefixLength}}
+{{if .Gateway}}Gateway={{.Gateway}}
+{{end}}{{range .DnsServers}}DNS={{.}}
+{{end}}{{if .Domain}}Domains={{.Domain}}
+{{end}}{{end}}
+{{- define "nmconnection"}}[connection]
+id=docker-machine-ov
+type=ethernet
+autoconnect-priority=100
+
+[ipv4]
+method=manual
+address1={{.Address}}/{{.PrefixLength}}{{if .Gateway}},{{.Gateway}}{{end}}
+{{if .DnsServers}}dns={{join .DnsServers ";"}};
+{{end}}{{if .Domain}}dns-search={{.Domain}};
+{{end}}{{end}}
+{
+  "ignition": {
+    "version": "3.3.0"
+  },
+  "passwd": {
+    "users": [
+      {
+        "name": "core",
+        "sshAuthorizedKeys": [{{json .SshPublicKey}}]
+      }
+    ]
+  },
+  "storage": {
+    "files": [
+      {
+        "path": "/etc/hostname",
+        "mode": 420,
+        "overwrite": true,
+        "contents": {
+          "source": {{json (dataurl .Hostname)}}
+        }
+      },
+      {
+        "path": "/etc/systemd/network/00-docker-machine-ov.network",
+        "mode": 420,
+        "overwrite": true,
+        "contents": {
+          "source": {{json (dataurl (include "networkd" .))}}
+        }
+      },
+      {
+        "path": "/etc/NetworkManager/system-connections/docker-machine-ov.nmconnection",
+        "mode": 384,
+        "overwrite": true,
+        "contents": {
+          "source": {{json (dataurl (include "nmconnection" .))}}
+        }
+      }
+    ]
+  }
+}
+`
+
+// Check Ignition config can be parsed and core user can log in with key
+func validateIgnition(config []byte) error {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(config, &top); err != nil {
+		return fmt.Errorf("%s is not valid JSON: %v", ignitionFileName, err)
+	}
+	for key := range top {
+		if !ignitionKeys[key] {
+			return fmt.Errorf("Unknown key in %s: %s", ignitionFileName, key)
+		}
+	}
+
+	var ignition struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+		Passwd struct {
+			Users []struct {
+				Name              string   `json:"name"`
+				SshAuthorizedKeys []string `json:"sshAuthorizedKeys"`
+			} `json:"users"`
+		} `json:"passwd"`
+	}
+	if err := json.Unmarshal(config, &ignition); err != nil {
+		return fmt.Errorf("%s is not valid Ignition config: %v", ignitionFileName, err)
+	}
+	if !strings.HasPrefix(ignition.Ignition.Version, "3.") {
+		return fmt.Errorf("Ignition spec version should be 3.x: %q", ignition.Ignition.Version)
+	}
+	for _, user := range ignition.Passwd.Users {
+		if user.Name == ignitionUser && len(user.SshAuthorizedKeys) > 0 && user.SshAuthorizedKeys[0] != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s user should have SSH public key in %s", ignitionUser, ignitionFileName)
+}
@@ -0,0 +1,80 @@
+package driver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestIgnitionConfig(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	defer cleanup()
+	s.InstallMethod = installMethodIgnition
+
+	if err := s.validateKickstart(); err != nil {
+		t.Fatal(err)
+	}
+	config, err := s.RenderKickstart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ignition struct {
+		Passwd struct {
+			Users []struct {
+				Name              string   `json:"name"`
+				SshAuthorizedKeys []string `json:"sshAuthorizedKeys"`
+			} `json:"users"`
+		} `json:"passwd"`
+		Storage struct {
+			Files []struct {
+				Path     string `json:"path"`
+				Contents struct {
+					Source string `json:"source"`
+				} `json:"contents"`
+			} `json:"files"`
+		} `json:"storage"`
+	}
+	if err := json.Unmarshal(config, &ignition); err != nil {
+		t.Fatal(err)
+	}
+	if ignition.Passwd.Users[0].Name != "core" || ignition.Passwd.Users[0].SshAuthorizedKeys[0] != s.SshPublicKey {
+		t.Errorf("Unexpected users: %+v", ignition.Passwd.Users)
+	}
+	files := map[string]string{}
+	for _, f := range ignition.Storage.Files {
+		data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(f.Contents.Source, "data:;base64,"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Path] = string(data)
+	}
+	if files["/etc/hostname"] != "node1" {
+		t.Errorf("Unexpected hostname: %q", files["/etc/hostname"])
+	}
+	if !strings.Contains(files["/etc/systemd/network/00-docker-machine-ov.network"], "Address=192.168.1.10/24\nGateway=192.168.1.1\nDNS=192.168.1.2\nDNS=192.168.1.3\n") {
+		t.Errorf("Unexpected networkd config: %s", files["/etc/systemd/network/00-docker-machine-ov.network"])
+	}
+	if !strings.Contains(files["/etc/NetworkManager/system-connections/docker-machine-ov.nmconnection"], "address1=192.168.1.10/24,192.168.1.1\ndns=192.168.1.2;192.168.1.3;\n") {
+		t.Errorf("Unexpected NetworkManager config: %s", files["/etc/NetworkManager/system-connections/docker-machine-ov.nmconnection"])
+	}
+
+	// Driver logs in as core with key
+	if s.sshUser() != "core" || !s.installMethod().keyOnly {
+		t.Errorf("Unexpected ssh login: %s %v", s.sshUser(), s.installMethod().keyOnly)
+	}
+}
+
+func TestIgnitionInvalid(t *testing.T) {
+	for _, config := range []string{
+		`{"ignition": {"version": "3.3.0"}`,
+		`{"ignition": {"version": "2.3.0"}, "passwd": {"users": [{"name": "core", "sshAuthorizedKeys": ["ssh-rsa AAAA"]}]}}`,
+		`{"ignition": {"version": "3.3.0"}, "passwd": {"users": [{"name": "core"}]}}`,
+		`{"ignition": {"version": "3.3.0"}, "password": {}}`,
+		`{"ignition": {"version": "3.3.0"}, "passwd": {"users": [{"name": "core", "sshAuthorizedKeys": "ssh-rsa AAAA"}]}}`,
+	} {
+		if err := validateIgnition([]byte(config)); err == nil {
+			t.Errorf("Invalid Ignition config should be error: %s", config)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
@@ -18,6 +20,7 @@ type IloClient struct {
 	Model            string
 	//	VirtualMedia     *IloVirtualMedia
 	VirtualDevices *VirtualDevices
+	Tls            *TlsConfig `json:"-"`
 }
 
 type VirtualDevices struct {
@@ -31,9 +34,13 @@ type IloVirtualMedias struct {
 }
 
 type IloVirtualMedia struct {
-	Id         string                 `json:"@odata.id"`
-	MediaTypes []string               `json:"MediaTypes"`
-	Actions    IloVirtualMediaActions `json:"Actions"`
+	Id                    string                 `json:"@odata.id"`
+	MediaTypes            []string               `json:"MediaTypes"`
+	Image                 string                 `json:"Image"`
+	Inserted              bool                   `json:"Inserted"`
+	Actions               IloVirtualMediaActions `json:"Actions"`
+	TransferProtocolTypes []string               `json:"-"` // Protocols accepted by insert action. Empty if not advertised.
+	Method                string                 `json:"-"` // How image is inserted and ejected
 }
 
 type IloVirtualMediaActions struct {
@@ -41,23 +48,23 @@ type IloVirtualMediaActions struct {
 	Eject  IloVirtualMediaActionTarget
 }
 
-type Ilo5VirtualMedia struct {
-	Id         string                  `json:"@odata.id"`
-	MediaTypes []string                `json:"MediaTypes"`
-	Actions    Ilo5VirtualMediaActions `json:"Actions"`
+// Virtual media resource of any Redfish service.
+// Standard DMTF actions and iLO4 OEM actions are both read.
+type RedfishVirtualMedia struct {
+	Id                   string                     `json:"@odata.id"`
+	MediaTypes           []string                   `json:"MediaTypes"`
+	Image                string                     `json:"Image"`
+	Inserted             bool                       `json:"Inserted"`
+	TransferProtocolType string                     `json:"TransferProtocolType"`
+	Actions              RedfishVirtualMediaActions `json:"Actions"`
+	Oem                  Ilo4VirtualMediaOem        `json:"Oem"`
 }
 
-type Ilo5VirtualMediaActions struct {
+type RedfishVirtualMediaActions struct {
 	Insert IloVirtualMediaActionTarget `json:"#VirtualMedia.InsertMedia"`
 	Eject  IloVirtualMediaActionTarget `json:"#VirtualMedia.EjectMedia"`
 }
 
-type Ilo4VirtualMedia struct {
-	Id         string              `json:"@odata.id"`
-	MediaTypes []string            `json:"MediaTypes"`
-	Oem        Ilo4VirtualMediaOem `json:"Oem"`
-}
-
 type Ilo4VirtualMediaOem struct {
 	Hp Ilo4VirtualMediaOemHp `json:"Hp"`
 }
@@ -72,7 +79,8 @@ type Ilo4VirtualMediaActions struct {
 }
 
 type IloVirtualMediaActionTarget struct {
-	Target string `json:"target"`
+	Target                string   `json:"target"`
+	TransferProtocolTypes []string `json:"TransferProtocolType@Redfish.AllowableValues"`
 }
 
 type IloVirtualMediaMember struct {
@@ -80,9 +88,17 @@ type IloVirtualMediaMember struct {
 }
 
 type IloInsertVirtualMediaReqBody struct {
-	Image string `json:"Image"`
+	Image                string `json:"Image"`
+	TransferProtocolType string `json:"TransferProtocolType,omitempty"`
 }
 
+// How image is inserted into and ejected from virtual media
+const (
+	virtualMediaAction    = "action"     // DMTF #VirtualMedia.InsertMedia and #VirtualMedia.EjectMedia
+	virtualMediaOemAction = "oem-action" // iLO4 #HpiLOVirtualMedia.InsertVirtualMedia and EjectVirtualMedia
+	virtualMediaPatch     = "patch"      // PATCH Image of virtual media resource
+)
+
 func (s *HpeConfig) NewIloClient() (*IloClient, error) {
 	log.Info("Create new HPE iLO client")
 	log.Debugf("HpeConfig: %#v", s)
@@ -121,6 +137,7 @@ func (s *HpeConfig) NewIloClient() (*IloClient, error) {
 	iloClient.Address = hardware.MpHostInfo.MpIPAddresses[1].Address
 	iloClient.Token = strings.Replace(iloClient.RemoteConsoleUrl, fmt.Sprintf("hplocons://addr=%v&sessionkey=", iloClient.Address), "", -1)
 	iloClient.Model = hardware.MpModel
+	iloClient.Tls = s.Oneview.tlsConfig()
 	log.Debugf("iloClient: %#v", iloClient)
 
 	if iloClient.Address == "" {
@@ -138,7 +155,7 @@ func (s *HpeConfig) NewIloClient() (*IloClient, error) {
 }
 
 func (ilo *IloClient) GetVirtualMedia() error {
-	log.Info("Get iLO Virtual Media infomation")
+	log.Infof("Get %s virtual media infomation", ilo.Model)
 
 	// Create RedFish client
 	c, err := ilo.createRedfishClient()
@@ -155,118 +172,183 @@ func (ilo *IloClient) GetVirtualMedia() error {
 		log.Error(Wrap(err))
 		return err
 	}
-
-	// Retrieve virtual media members on HPE iLO
-	// Gofish seems not support virtual media...
-	resVirtualMedia, err := c.Get(fmt.Sprintf("%vVirtualMedia/", manager[0].Entity.ODataID))
-	if err != nil {
+	if len(manager) == 0 {
+		err := fmt.Errorf("Could not find manager on HPE iLO %s", ilo.Address)
 		log.Error(Wrap(err))
 		return err
 	}
-	defer resVirtualMedia.Body.Close()
 
-	var virtualMedias IloVirtualMedias
-	body, err := ioutil.ReadAll(resVirtualMedia.Body)
-	if err != nil {
+	// Retrieve virtual media members on HPE iLO
+	// Gofish seems not support virtual media...
+	var managerLinks struct {
+		VirtualMedia IloVirtualMediaMember `json:"VirtualMedia"`
+	}
+	if err := ilo.getRedfishResource(c, manager[0].Entity.ODataID, &managerLinks); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
-	if err := json.Unmarshal(body, &virtualMedias); err != nil {
+	if managerLinks.VirtualMedia.Id == "" {
+		managerLinks.VirtualMedia.Id = strings.TrimSuffix(manager[0].Entity.ODataID, "/") + "/VirtualMedia/"
+	}
+	var virtualMedias IloVirtualMedias
+	if err := ilo.getRedfishResource(c, managerLinks.VirtualMedia.Id, &virtualMedias); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
 
 	virtualDevices := &VirtualDevices{}
 	for _, virtualMediaMember := range virtualMedias.Members {
-		res, err := c.Get(virtualMediaMember.Id)
-		body, err = ioutil.ReadAll(res.Body)
+		var body json.RawMessage
+		if err := ilo.getRedfishResource(c, virtualMediaMember.Id, &body); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		log.Debugf("%v", string(body))
+		virtualMedia, err := parseVirtualMedia(body)
 		if err != nil {
 			log.Error(Wrap(err))
 			return err
 		}
 
-		iloModel := ilo.Model
-		switch iloModel {
-		case "iLO4":
-			var virtualMedia Ilo4VirtualMedia
-			if err := json.Unmarshal(body, &virtualMedia); err != nil {
-				log.Error(Wrap(err))
-				return err
-			}
+		switch virtualMediaDeviceType(virtualMedia.MediaTypes) {
+		case "dvd":
+			log.Infof("DVD virtual media detected. Image is inserted by %s", virtualMedia.Method)
+			log.Debugf("Virtual DVD: %#v", virtualMedia)
+			virtualDevices.Dvd = *virtualMedia
+		case "floppy":
+			log.Infof("Floppy virtual media detected. Image is inserted by %s", virtualMedia.Method)
+			log.Debugf("Virtual Floppy: %#v", virtualMedia)
+			virtualDevices.Floppy = *virtualMedia
+		}
+	}
+	ilo.VirtualDevices = virtualDevices
+	log.Debugf("Virtual Devices: %#v", ilo.VirtualDevices)
 
-			for _, mediaType := range virtualMedia.MediaTypes {
-				if mediaType == "DVD" {
-					log.Info("iLO4 DVD virtual media detected")
-					log.Debugf("Virtual DVD: %#v", virtualMedia)
-					virtualDevices.Dvd = IloVirtualMedia{
-						Id:         virtualMedia.Id,
-						MediaTypes: virtualMedia.MediaTypes,
-						Actions: IloVirtualMediaActions{
-							Insert: virtualMedia.Oem.Hp.Actions.Insert,
-							Eject:  virtualMedia.Oem.Hp.Actions.Eject,
-						},
-					}
-				}
-				if mediaType == "Floppy" {
-					log.Info("iLO4 Floppy virtual media detected")
-					log.Debugf("Virtual Floppy: %#v", virtualMedia)
-					virtualDevices.Floppy = IloVirtualMedia{
-						Id:         virtualMedia.Id,
-						MediaTypes: virtualMedia.MediaTypes,
-						Actions: IloVirtualMediaActions{
-							Insert: virtualMedia.Oem.Hp.Actions.Insert,
-							Eject:  virtualMedia.Oem.Hp.Actions.Eject,
-						},
-					}
-				}
-			}
-			ilo.VirtualDevices = virtualDevices
+	return nil
+}
 
-		case "iLO5":
-			var virtualMedia Ilo5VirtualMedia
-			if err := json.Unmarshal(body, &virtualMedia); err != nil {
-				log.Error(Wrap(err))
-				return err
-			}
-			log.Debugf("%v", string(body))
-
-			for _, mediaType := range virtualMedia.MediaTypes {
-				if mediaType == "DVD" {
-					log.Info("iLO5 DVD virtual media detected")
-					log.Debugf("Virtual DVD: %#v", virtualMedia)
-					virtualDevices.Dvd = IloVirtualMedia{
-						Id:         virtualMedia.Id,
-						MediaTypes: virtualMedia.MediaTypes,
-						Actions: IloVirtualMediaActions{
-							Insert: virtualMedia.Actions.Insert,
-							Eject:  virtualMedia.Actions.Eject,
-						},
-					}
-				}
-				if mediaType == "Floppy" {
-					log.Info("iLO5 Floppy virtual media detected")
-					log.Debugf("Virtual Floppy: %#v", virtualMedia)
-					virtualDevices.Floppy = IloVirtualMedia{
-						Id:         virtualMedia.Id,
-						MediaTypes: virtualMedia.MediaTypes,
-						Actions: IloVirtualMediaActions{
-							Insert: virtualMedia.Actions.Insert,
-							Eject:  virtualMedia.Actions.Eject,
-						},
-					}
-				}
-			}
-			ilo.VirtualDevices = virtualDevices
+// Read JSON of Redfish resource
+func (ilo *IloClient) getRedfishResource(c *gofish.APIClient, uri string, v interface{}) error {
+	res, err := c.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
 
-		default:
-			err := fmt.Errorf("%s is not supported", iloModel)
-			log.Error(Wrap(err))
-			return err
+// Capabilities of virtual media are discovered from resource.
+// Standard actions are preferred, then iLO4 OEM actions, then PATCH of Image.
+func parseVirtualMedia(body []byte) (*IloVirtualMedia, error) {
+	var resource RedfishVirtualMedia
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return nil, err
+	}
+	virtualMedia := &IloVirtualMedia{
+		Id:         resource.Id,
+		MediaTypes: resource.MediaTypes,
+		Image:      resource.Image,
+		Inserted:   resource.Inserted,
+	}
+	switch {
+	case resource.Actions.Insert.Target != "":
+		virtualMedia.Method = virtualMediaAction
+		virtualMedia.Actions = IloVirtualMediaActions{
+			Insert: resource.Actions.Insert,
+			Eject:  resource.Actions.Eject,
 		}
+		virtualMedia.TransferProtocolTypes = resource.Actions.Insert.TransferProtocolTypes
+	case resource.Oem.Hp.Actions.Insert.Target != "":
+		virtualMedia.Method = virtualMediaOemAction
+		virtualMedia.Actions = IloVirtualMediaActions{
+			Insert: resource.Oem.Hp.Actions.Insert,
+			Eject:  resource.Oem.Hp.Actions.Eject,
+		}
+	default:
+		virtualMedia.Method = virtualMediaPatch
 	}
-	log.Debugf("Virtual Devices: %#v", ilo.VirtualDevices)
+	return virtualMedia, nil
+}
 
-	return nil
+// Redfish media types of virtual DVD and virtual floppy
+func virtualMediaDeviceType(mediaTypes []string) string {
+	for _, mediaType := range mediaTypes {
+		switch mediaType {
+		case "CD", "DVD":
+			return "dvd"
+		case "Floppy", "USBStick":
+			return "floppy"
+		}
+	}
+	return ""
+}
+
+// HTTP method, URI and body to insert image
+func (vm *IloVirtualMedia) insertRequest(imageUrl string) (string, string, interface{}, error) {
+	u, err := url.Parse(imageUrl)
+	if err != nil {
+		return "", "", nil, err
+	}
+	protocol := strings.ToUpper(u.Scheme)
+	req := IloInsertVirtualMediaReqBody{
+		Image: imageUrl,
+	}
+	if len(vm.TransferProtocolTypes) > 0 {
+		supported := false
+		for _, p := range vm.TransferProtocolTypes {
+			if strings.EqualFold(p, protocol) {
+				supported = true
+			}
+		}
+		if !supported {
+			return "", "", nil, fmt.Errorf("Virtual media %s does not support %s. Supported protocols are %s", vm.Id, protocol, strings.Join(vm.TransferProtocolTypes, ", "))
+		}
+		req.TransferProtocolType = protocol
+	}
+
+	switch vm.Method {
+	case virtualMediaAction, virtualMediaOemAction:
+		return http.MethodPost, vm.Actions.Insert.Target, req, nil
+	default:
+		return http.MethodPatch, vm.Id, map[string]interface{}{"Image": imageUrl, "Inserted": true}, nil
+	}
+}
+
+// HTTP method, URI and body to eject image.
+// EjectMedia and iLO4 EjectVirtualMedia have no parameters, so empty JSON object is posted.
+func (vm *IloVirtualMedia) ejectRequest() (string, string, interface{}) {
+	switch {
+	case (vm.Method == virtualMediaAction || vm.Method == virtualMediaOemAction) && vm.Actions.Eject.Target != "":
+		return http.MethodPost, vm.Actions.Eject.Target, struct{}{}
+	default:
+		return http.MethodPatch, vm.Id, map[string]interface{}{"Image": nil, "Inserted": false}
+	}
+}
+
+func (ilo *IloClient) virtualDevice(deviceType string) (*IloVirtualMedia, error) {
+	var tagertDevice IloVirtualMedia
+	if strings.ToLower(deviceType) == "dvd" {
+		tagertDevice = ilo.VirtualDevices.Dvd
+	} else if strings.ToLower(deviceType) == "floppy" {
+		tagertDevice = ilo.VirtualDevices.Floppy
+	} else {
+		return nil, fmt.Errorf("Unknown device type: %s", deviceType)
+	}
+	if tagertDevice.Id == "" {
+		return nil, fmt.Errorf("Virtual %s device is not found on HPE iLO %s", deviceType, ilo.Address)
+	}
+	return &tagertDevice, nil
+}
+
+func sendVirtualMediaRequest(c *gofish.APIClient, method, uri string, body interface{}) (*http.Response, error) {
+	if method == http.MethodPatch {
+		return c.Patch(uri, body)
+	}
+	return c.Post(uri, body)
 }
 
 // Insert virtual media to HPE iLO
@@ -288,23 +370,19 @@ func (ilo *IloClient) InsertVirtualMedia(imageUrl, deviceType string) error {
 	defer c.Logout()
 
 	// Insert target virtual media URL
-	var tagertDevice IloVirtualMedia
-	if strings.ToLower(deviceType) == "dvd" {
-		log.Infof("Insert %s into virtual DVD device.", imageUrl)
-		tagertDevice = ilo.VirtualDevices.Dvd
-	} else if strings.ToLower(deviceType) == "floppy" {
-		log.Infof("Insert %s into virtual Floppy device.", imageUrl)
-		tagertDevice = ilo.VirtualDevices.Floppy
-	} else {
-		err := fmt.Errorf("Unknown device type: %s", deviceType)
+	tagertDevice, err := ilo.virtualDevice(deviceType)
+	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
-	log.Debugf("Target virtual media action endpoint is %v", tagertDevice.Actions.Insert.Target)
-	req := IloInsertVirtualMediaReqBody{
-		Image: imageUrl,
+	log.Infof("Insert %s into virtual %s device.", imageUrl, deviceType)
+	method, target, req, err := tagertDevice.insertRequest(imageUrl)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
 	}
-	res, err := c.Post(tagertDevice.Actions.Insert.Target, req)
+	log.Debugf("Target virtual media endpoint is %s %v", method, target)
+	res, err := sendVirtualMediaRequest(c, method, target, req)
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
@@ -336,22 +414,15 @@ func (ilo *IloClient) EjectVirtualMedia(deviceType string) error {
 	defer c.Logout()
 
 	// Eject target virtual media URL
-	var tagertDevice IloVirtualMedia
-	if strings.ToLower(deviceType) == "dvd" {
-		log.Info("Eject image from virtual DVD device.")
-		tagertDevice = ilo.VirtualDevices.Dvd
-	} else if strings.ToLower(deviceType) == "floppy" {
-		log.Info("Eject image from virtual Floppy device.")
-		tagertDevice = ilo.VirtualDevices.Floppy
-	} else {
-		err := fmt.Errorf("Unknown device type: %s", deviceType)
+	tagertDevice, err := ilo.virtualDevice(deviceType)
+	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
-
-	log.Debug(tagertDevice.Actions.Eject.Target)
-	var req map[string]string // iLO4 need null body? or json header.
-	res, err := c.Post(tagertDevice.Actions.Eject.Target, req)
+	log.Infof("Eject image from virtual %s device.", deviceType)
+	method, target, req := tagertDevice.ejectRequest()
+	log.Debugf("Target virtual media endpoint is %s %v", method, target)
+	res, err := sendVirtualMediaRequest(c, method, target, req)
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
@@ -365,16 +436,40 @@ func (ilo *IloClient) EjectVirtualMedia(deviceType string) error {
 	return nil
 }
 
+// Check image is inserted into iLO virtual media
+func (ilo *IloClient) IsVirtualMediaInserted(imageUrl, deviceType string) (bool, error) {
+	err := ilo.GetVirtualMedia()
+	if err != nil {
+		log.Error(Wrap(err))
+		return false, err
+	}
+
+	tagertDevice, err := ilo.virtualDevice(deviceType)
+	if err != nil {
+		log.Error(Wrap(err))
+		return false, err
+	}
+	log.Debugf("Virtual %s: inserted=%v image=%s", deviceType, tagertDevice.Inserted, tagertDevice.Image)
+
+	return tagertDevice.Inserted && tagertDevice.Image == imageUrl, nil
+}
+
 func (ilo *IloClient) createRedfishClient() (*gofish.APIClient, error) {
+	// Certificate is verified with our http client
+	httpClient, err := ilo.Tls.HttpClient(ilo.Address, "")
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
 	// Create RedFish client
 	config := gofish.ClientConfig{
 		Endpoint: "https://" + ilo.Address,
 		Session: &gofish.Session{
 			Token: ilo.Token,
 		},
-		TLSHandshakeTimeout: 1,
-		Insecure:            true,
-		BasicAuth:           false,
+		HTTPClient: httpClient,
+		BasicAuth:  false,
 	}
 	c, err := gofish.Connect(config)
 	if err != nil {
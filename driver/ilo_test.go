@@ -1,6 +1,12 @@
 package driver
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	log "github.com/docker/machine/libmachine/log"
@@ -65,3 +71,217 @@ func TestIloVirtualMediaActions(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// Virtual media resources of each iLO generation
+const (
+	iloTestIlo4VirtualMedia = `{
+  "@odata.id": "/redfish/v1/Managers/1/VirtualMedia/2/",
+  "MediaTypes": ["CD", "DVD"],
+  "Image": "",
+  "Inserted": false,
+  "Oem": {"Hp": {"Actions": {
+    "#HpiLOVirtualMedia.InsertVirtualMedia": {"target": "/redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hp/HpiLOVirtualMedia.InsertVirtualMedia/"},
+    "#HpiLOVirtualMedia.EjectVirtualMedia": {"target": "/redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hp/HpiLOVirtualMedia.EjectVirtualMedia/"}
+  }}}
+}`
+	iloTestIlo5VirtualMedia = `{
+  "@odata.id": "/redfish/v1/Managers/1/VirtualMedia/1/",
+  "MediaTypes": ["Floppy", "USBStick"],
+  "Image": "http://192.168.1.5/ks/192.168.1.10.iso",
+  "Inserted": true,
+  "Actions": {
+    "#VirtualMedia.InsertMedia": {"target": "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia/"},
+    "#VirtualMedia.EjectMedia": {"target": "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia/"}
+  },
+  "Oem": {"Hpe": {"Actions": {}}}
+}`
+	iloTestIlo6VirtualMedia = `{
+  "@odata.id": "/redfish/v1/Managers/1/VirtualMedia/2",
+  "MediaTypes": ["CD", "DVD"],
+  "Image": "",
+  "Inserted": false,
+  "TransferProtocolType": "HTTPS",
+  "Actions": {
+    "#VirtualMedia.InsertMedia": {
+      "target": "/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia",
+      "TransferProtocolType@Redfish.AllowableValues": ["HTTP", "HTTPS"]
+    },
+    "#VirtualMedia.EjectMedia": {"target": "/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia"}
+  }
+}`
+)
+
+func TestIloVirtualMediaCapabilities(t *testing.T) {
+	for _, tc := range []struct {
+		body       string
+		deviceType string
+		method     string
+		insert     string
+		eject      string
+		ejectBody  string
+	}{
+		{iloTestIlo4VirtualMedia, "dvd", virtualMediaOemAction, "/redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hp/HpiLOVirtualMedia.InsertVirtualMedia/",
+			"/redfish/v1/Managers/1/VirtualMedia/2/Actions/Oem/Hp/HpiLOVirtualMedia.EjectVirtualMedia/", `{}`},
+		{iloTestIlo5VirtualMedia, "floppy", virtualMediaAction, "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia/",
+			"/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia/", `{}`},
+		{iloTestIlo6VirtualMedia, "dvd", virtualMediaAction, "/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia",
+			"/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia", `{}`},
+		{`{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/3", "MediaTypes": ["USBStick"]}`, "floppy", virtualMediaPatch, "/redfish/v1/Managers/1/VirtualMedia/3",
+			"/redfish/v1/Managers/1/VirtualMedia/3", `{"Image":null,"Inserted":false}`},
+	} {
+		vm, err := parseVirtualMedia([]byte(tc.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if virtualMediaDeviceType(vm.MediaTypes) != tc.deviceType || vm.Method != tc.method {
+			t.Errorf("Unexpected virtual media %s: %s %s", vm.Id, virtualMediaDeviceType(vm.MediaTypes), vm.Method)
+		}
+		_, target, _, err := vm.insertRequest("http://192.168.1.5/os.iso")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if target != tc.insert {
+			t.Errorf("Unexpected insert target of %s: %s", vm.Id, target)
+		}
+		_, target, body := vm.ejectRequest()
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if target != tc.eject || string(data) != tc.ejectBody {
+			t.Errorf("Unexpected eject request of %s: %s %s", vm.Id, target, data)
+		}
+	}
+
+	// Protocol is checked with allowable values
+	vm, err := parseVirtualMedia([]byte(iloTestIlo6VirtualMedia))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, req, err := vm.insertRequest("https://192.168.1.5/os.iso")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.(IloInsertVirtualMediaReqBody).TransferProtocolType != "HTTPS" {
+		t.Errorf("Transfer protocol should be set: %#v", req)
+	}
+	if _, _, _, err := vm.insertRequest("nfs://192.168.1.5/os.iso"); err == nil {
+		t.Error("Unsupported protocol should be error")
+	}
+}
+
+type testRedfishRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+// Fake iLO 6 Redfish service with virtual DVD.
+// PATCH request is merged into resource.
+func createTestRedfishServer(t *testing.T) (*httptest.Server, *[]testRedfishRequest) {
+	var mu sync.Mutex
+	var requests []testRedfishRequest
+	resources := map[string]string{
+		"/redfish/v1/":                          `{"@odata.id": "/redfish/v1/", "Managers": {"@odata.id": "/redfish/v1/Managers"}, "Systems": {"@odata.id": "/redfish/v1/Systems"}}`,
+		"/redfish/v1/Managers":                  `{"Members": [{"@odata.id": "/redfish/v1/Managers/1"}], "Members@odata.count": 1}`,
+		"/redfish/v1/Managers/1":                `{"@odata.id": "/redfish/v1/Managers/1", "Id": "1", "VirtualMedia": {"@odata.id": "/redfish/v1/Managers/1/VirtualMedia"}}`,
+		"/redfish/v1/Managers/1/VirtualMedia":   `{"Members": [{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/2"}], "Members@odata.count": 1}`,
+		"/redfish/v1/Managers/1/VirtualMedia/2": iloTestIlo6VirtualMedia,
+		"/redfish/v1/Systems":                   `{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}], "Members@odata.count": 1}`,
+		"/redfish/v1/Systems/1": `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Bios": {"@odata.id": "/redfish/v1/Systems/1/Bios"}, "Boot": {
+  "BootSourceOverrideEnabled": "Disabled",
+  "BootSourceOverrideTarget": "None",
+  "BootSourceOverrideMode": "UEFI",
+  "BootSourceOverrideTarget@Redfish.AllowableValues": ["None", "Pxe", "Cd", "Hdd", "UefiTarget", "UefiBootNext", "UefiHttp"],
+  "BootOptions": {"@odata.id": "/redfish/v1/Systems/1/BootOptions"}
+}}`,
+		"/redfish/v1/Systems/1/Bios":          `{"@odata.id": "/redfish/v1/Systems/1/Bios", "Attributes": {"BootMode": "Uefi", "UrlBootFile": ""}, "@Redfish.Settings": {"SettingsObject": {"@odata.id": "/redfish/v1/Systems/1/Bios/Settings"}}}`,
+		"/redfish/v1/Systems/1/Bios/Settings": `{"@odata.id": "/redfish/v1/Systems/1/Bios/Settings", "Attributes": {"BootMode": "Uefi", "UrlBootFile": ""}}`,
+		"/redfish/v1/Systems/1/BootOptions":   `{"Members": [{"@odata.id": "/redfish/v1/Systems/1/BootOptions/1"}, {"@odata.id": "/redfish/v1/Systems/1/BootOptions/2"}], "Members@odata.count": 2}`,
+		"/redfish/v1/Systems/1/BootOptions/1": `{"@odata.id": "/redfish/v1/Systems/1/BootOptions/1", "DisplayName": "Embedded LOM 1 Port 1 : HPE Ethernet 1Gb 4-port 331i Adapter - NIC (PXE IPv4)", "UefiDevicePath": "PciRoot(0x0)/Pci(0x1C,0x0)/Pci(0x0,0x0)/MAC(B88303000001,0x0)/IPv4(0.0.0.0)", "BootOptionReference": "Boot0009"}`,
+		"/redfish/v1/Systems/1/BootOptions/2": `{"@odata.id": "/redfish/v1/Systems/1/BootOptions/2", "DisplayName": "iLO Virtual USB 2 : HPE iLO Virtual USB CD/DVD ROM", "UefiDevicePath": "PciRoot(0x0)/Pci(0x14,0x0)/USB(0x13,0x0)/USB(0x0,0x0)/USB(0x1,0x0)", "BootOptionReference": "Boot000A"}`,
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, testRedfishRequest{r.Method, r.URL.Path, string(body)})
+		resource, ok := resources[r.URL.Path]
+		switch {
+		case r.Method == http.MethodPatch && ok:
+			var current, patch map[string]interface{}
+			if err := json.Unmarshal([]byte(resource), &current); err != nil {
+				t.Error(err)
+			}
+			if err := json.Unmarshal(body, &patch); err != nil {
+				t.Error(err)
+			}
+			mergeTestResource(current, patch)
+			merged, _ := json.Marshal(current)
+			resources[r.URL.Path] = string(merged)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method != http.MethodGet:
+			w.WriteHeader(http.StatusNoContent)
+		case !ok:
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(resource))
+		}
+	}))
+	return ts, &requests
+}
+
+func mergeTestResource(current, patch map[string]interface{}) {
+	for key, value := range patch {
+		if child, ok := value.(map[string]interface{}); ok {
+			if currentChild, ok := current[key].(map[string]interface{}); ok {
+				mergeTestResource(currentChild, child)
+				continue
+			}
+		}
+		current[key] = value
+	}
+}
+
+func TestIloVirtualMediaRedfish(t *testing.T) {
+	ts, requests := createTestRedfishServer(t)
+	defer ts.Close()
+	ilo := &IloClient{
+		Address: strings.TrimPrefix(ts.URL, "https://"),
+		Token:   "token",
+		Model:   "iLO6",
+		Tls:     &TlsConfig{Insecure: true},
+	}
+
+	if err := ilo.InsertVirtualMedia("http://192.168.1.5/os.iso", "dvd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ilo.EjectVirtualMedia("dvd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ilo.InsertVirtualMedia("http://192.168.1.5/ks.iso", "floppy"); err == nil {
+		t.Error("Missing virtual floppy should be error")
+	}
+
+	var actions []testRedfishRequest
+	for _, r := range *requests {
+		if r.method == http.MethodPost {
+			actions = append(actions, r)
+		}
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Unexpected actions: %+v", actions)
+	}
+	var insert map[string]string
+	if err := json.Unmarshal([]byte(actions[0].body), &insert); err != nil {
+		t.Fatal(err)
+	}
+	if actions[0].path != "/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.InsertMedia" ||
+		insert["Image"] != "http://192.168.1.5/os.iso" || insert["TransferProtocolType"] != "HTTP" {
+		t.Errorf("Unexpected insert action: %+v", actions[0])
+	}
+	if actions[1].path != "/redfish/v1/Managers/1/VirtualMedia/2/Actions/VirtualMedia.EjectMedia" || actions[1].body != "{}" {
+		t.Errorf("Unexpected eject action: %+v", actions[1])
+	}
+}
@@ -0,0 +1,147 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	installMethodKickstart   = "kickstart"
+	installMethodAutoinstall = "autoinstall"
+	installMethodAutoyast    = "autoyast"
+	installMethodIgnition    = "ignition"
+)
+
+// Install config which is rendered from template and put on labeled image
+type installMethod struct {
+	template   string
+	label      string // Default volume label which installer looks for
+	fixedLabel bool   // Installer finds image only by default label
+	fileName   string
+	fatName    bool // File names can be used in FAT image
+	validate   func(config []byte) error
+	extraFiles func(s *Server) map[string][]byte
+	sshUser    string
+	keyOnly    bool // Password login is not enabled by install config
+}
+
+var installMethods = map[string]*installMethod{
+	installMethodKickstart: {
+		template: defaultKickstartTemplate,
+		label:    defaultKsLabel,
+		fileName: ksFileName,
+		fatName:  true,
+		sshUser:  "root",
+	},
+	installMethodAutoinstall: {
+		template:   defaultAutoinstallTemplate,
+		label:      cidataLabel,
+		fixedLabel: true,
+		fileName:   userDataFileName,
+		validate:   validateUserData,
+		extraFiles: autoinstallMetaData,
+		sshUser:    "root",
+	},
+	installMethodAutoyast: {
+		template: defaultAutoyastTemplate,
+		label:    oemdrvLabel,
+		fileName: autoyastFileName,
+		fatName:  true,
+		validate: validateAutoyast,
+		sshUser:  "root",
+	},
+	installMethodIgnition: {
+		template: defaultIgnitionTemplate,
+		label:    ignitionLabel,
+		fileName: ignitionFileName,
+		fatName:  true,
+		validate: validateIgnition,
+		sshUser:  ignitionUser,
+		keyOnly:  true,
+	},
+}
+
+func (s *Server) installMethodName() string {
+	if s.InstallMethod == "" {
+		return installMethodKickstart
+	}
+	return strings.ToLower(s.InstallMethod)
+}
+
+// Kickstart is used for unknown install method, which is rejected by Validate
+func (s *Server) installMethod() *installMethod {
+	if m, ok := installMethods[s.installMethodName()]; ok {
+		return m
+	}
+	return installMethods[installMethodKickstart]
+}
+
+func (s *Server) validateInstallMethod() error {
+	name := s.installMethodName()
+	if _, ok := installMethods[name]; !ok {
+		var names []string
+		for n := range installMethods {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("Unknown install method: %s. Specify one of %s", s.InstallMethod, strings.Join(names, ", "))
+	}
+	if name != installMethodKickstart && !s.IsKickstartGenerated() {
+		return fmt.Errorf("Install config of %s is generated by driver. Specify kickstart output directory or HTTP address", name)
+	}
+	if s.PasswordLogin && s.installMethod().keyOnly {
+		return fmt.Errorf("Password login is not supported by %s", name)
+	}
+	return nil
+}
+
+// User which driver logs in installed OS as
+func (s *Server) sshUser() string {
+	if s.SshHardened {
+		return s.hardenedUser()
+	}
+	return s.installMethod().sshUser
+}
+
+// Files in generated image
+func (s *Server) installFiles(config []byte) map[string][]byte {
+	m := s.installMethod()
+	files := map[string][]byte{m.fileName: config}
+	if m.extraFiles != nil {
+		for name, data := range m.extraFiles(s) {
+			files[name] = data
+		}
+	}
+	return files
+}
+
+// Functions which can be used in templates
+func installTemplateFuncs(tmpl *template.Template) template.FuncMap {
+	return template.FuncMap{
+		"join": strings.Join,
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"xml": func(s string) (string, error) {
+			var buf bytes.Buffer
+			err := xml.EscapeText(&buf, []byte(s))
+			return buf.String(), err
+		},
+		"dataurl": func(s string) string {
+			return "data:;base64," + base64.StdEncoding.EncodeToString([]byte(s))
+		},
+		// Render template defined by {{define}} into string
+		"include": func(name string, data interface{}) (string, error) {
+			var buf bytes.Buffer
+			err := tmpl.ExecuteTemplate(&buf, name, data)
+			return buf.String(), err
+		},
+	}
+}
@@ -0,0 +1,283 @@
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/docker/machine/libmachine/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ipamLockTimeout  = 30 //sec
+	ipamLeasesSuffix = ".leases"
+)
+
+// Interval to retry locking IPAM file
+var ipamLockInterval = 100 * time.Millisecond
+
+// Address pool managed in local yaml file.
+// Pool file is only read. Leases are kept in <pool file>.leases next to it.
+type IpamFile struct {
+	Path    string            `yaml:"-"`
+	Subnets []IpamSubnet      `yaml:"subnets"`
+	Leases  map[string]string `yaml:"-"` // IP address and machine name
+}
+
+type IpamSubnet struct {
+	Cidr       string   `yaml:"cidr"`
+	Gateway    string   `yaml:"gateway,omitempty"`
+	DnsServers []string `yaml:"dns-servers,omitempty"`
+	Domain     string   `yaml:"domain,omitempty"`
+	Exclude    []string `yaml:"exclude,omitempty"` // Address or range such as 172.16.14.1-172.16.14.20
+}
+
+// Read and check IPAM file
+func LoadIpamFile(path string) (*IpamFile, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	f := &IpamFile{Path: path}
+	if err := yaml.UnmarshalStrict(bytes, f); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if err := f.Validate(); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	bytes, err = ioutil.ReadFile(f.leasesPath())
+	if err != nil && !os.IsNotExist(err) {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(bytes, &f.Leases); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *IpamFile) leasesPath() string {
+	return f.Path + ipamLeasesSuffix
+}
+
+func (f *IpamFile) Validate() error {
+	if len(f.Subnets) == 0 {
+		err := fmt.Errorf("No subnet is defined in %s", f.Path)
+		log.Error(Wrap(err))
+		return err
+	}
+	for _, subnet := range f.Subnets {
+		_, ipNet, err := net.ParseCIDR(subnet.Cidr)
+		if err != nil || ipNet.IP.To4() == nil {
+			err := fmt.Errorf("Invalid IPv4 CIDR %s in %s", subnet.Cidr, f.Path)
+			log.Error(Wrap(err))
+			return err
+		}
+		if subnet.Gateway != "" && !ipNet.Contains(net.ParseIP(subnet.Gateway)) {
+			err := fmt.Errorf("Gateway %s is not in %s", subnet.Gateway, subnet.Cidr)
+			log.Error(Wrap(err))
+			return err
+		}
+		for _, dns := range subnet.DnsServers {
+			if net.ParseIP(dns) == nil {
+				err := fmt.Errorf("Invalid DNS server %s in %s", dns, f.Path)
+				log.Error(Wrap(err))
+				return err
+			}
+		}
+		for _, exclude := range subnet.Exclude {
+			if _, _, err := parseIpRange(exclude); err != nil {
+				log.Error(Wrap(err))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Write leases file atomically
+func (f *IpamFile) save() error {
+	bytes, err := yaml.Marshal(f.Leases)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	tmpPath := fmt.Sprintf("%s.%d.tmp", f.leasesPath(), os.Getpid())
+	if err := ioutil.WriteFile(tmpPath, bytes, 0600); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := os.Rename(tmpPath, f.leasesPath()); err != nil {
+		os.Remove(tmpPath)
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Give free address to owner. Address already leased to owner is returned again.
+func AllocateFromIpam(path, owner string) (*IpAllocation, error) {
+	var allocation *IpAllocation
+	err := updateIpamFile(path, func(f *IpamFile) error {
+		if f.Leases == nil {
+			f.Leases = map[string]string{}
+		}
+		leased := ""
+		for address, leaseOwner := range f.Leases {
+			if leaseOwner == owner {
+				log.Infof("IP address %s is already leased to %s", address, owner)
+				leased = address
+			}
+		}
+		for _, subnet := range f.Subnets {
+			_, ipNet, _ := net.ParseCIDR(subnet.Cidr)
+			address := leased
+			if address != "" && !ipNet.Contains(net.ParseIP(address)) {
+				continue
+			}
+			if address == "" {
+				address = f.freeAddress(subnet, ipNet)
+			}
+			if address == "" {
+				log.Infof("No free IP address in %s", subnet.Cidr)
+				continue
+			}
+			f.Leases[address] = owner
+			allocation = &IpAllocation{
+				Address:    address,
+				Netmask:    net.IP(ipNet.Mask).String(),
+				Gateway:    subnet.Gateway,
+				DnsServers: subnet.DnsServers,
+				Domain:     subnet.Domain,
+				IpamFile:   path,
+			}
+			return nil
+		}
+		return fmt.Errorf("No free IP address in %s", path)
+	})
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	log.Infof("IP address %s is allocated from %s", allocation.Address, path)
+	return allocation, nil
+}
+
+// Remove lease of owner
+func ReleaseFromIpam(path, address, owner string) error {
+	return updateIpamFile(path, func(f *IpamFile) error {
+		leaseOwner, ok := f.Leases[address]
+		if !ok {
+			log.Warnf("IP address %s is not leased in %s. Skip to release", address, path)
+			return nil
+		}
+		if leaseOwner != owner {
+			log.Warnf("IP address %s is leased to %s. Skip to release", address, leaseOwner)
+			return nil
+		}
+		log.Infof("Release IP address %s", address)
+		delete(f.Leases, address)
+		return nil
+	})
+}
+
+// Check address is still leased to owner
+func CheckIpamLease(path, address, owner string) error {
+	f, err := LoadIpamFile(path)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if f.Leases[address] != owner {
+		err := fmt.Errorf("IP address %s is not leased to %s in %s", address, owner, path)
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// First address in subnet which is not network, broadcast, gateway, excluded or leased
+func (f *IpamFile) freeAddress(subnet IpamSubnet, ipNet *net.IPNet) string {
+	network := ipNet.IP.To4()
+	broadcast := make(net.IP, len(network))
+	for i := range network {
+		broadcast[i] = network[i] | ^ipNet.Mask[i]
+	}
+	for ip := nextIp(network); bytes.Compare(ip, broadcast) < 0; ip = nextIp(ip) {
+		address := ip.String()
+		if address == subnet.Gateway {
+			continue
+		}
+		if _, leased := f.Leases[address]; leased {
+			continue
+		}
+		excluded := false
+		for _, exclude := range subnet.Exclude {
+			start, end, _ := parseIpRange(exclude)
+			if ipInRange(ip, start, end) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			return address
+		}
+	}
+	return ""
+}
+
+// Lock, read, update and write leases of IPAM file
+func updateIpamFile(path string, update func(f *IpamFile) error) error {
+	lock, err := lockFile(path+".lock", ipamLockTimeout*time.Second, ipamLockInterval)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer lock.Unlock()
+
+	f, err := LoadIpamFile(path)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := update(f); err != nil {
+		return err
+	}
+	return f.save()
+}
+
+// Parse single address or range such as 172.16.14.1-172.16.14.20
+func parseIpRange(s string) (string, string, error) {
+	parts := strings.SplitN(s, "-", 2)
+	start := strings.TrimSpace(parts[0])
+	end := start
+	if len(parts) == 2 {
+		end = strings.TrimSpace(parts[1])
+	}
+	if net.ParseIP(start).To4() == nil || net.ParseIP(end).To4() == nil {
+		return "", "", fmt.Errorf("Invalid IPv4 address range %s", s)
+	}
+	return start, end, nil
+}
+
+func nextIp(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
@@ -0,0 +1,192 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testIpamYaml = `
+subnets:
+  - cidr: 172.16.14.0/29
+    gateway: 172.16.14.1
+    dns-servers: [172.16.14.2]
+    exclude: [172.16.14.2-172.16.14.3]
+  - cidr: 172.16.15.0/30
+    gateway: 172.16.15.1
+`
+
+func createTestIpamFile(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "ov-ipam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "ipam.yml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestIpamAllocate(t *testing.T) {
+	path, cleanup := createTestIpamFile(t, testIpamYaml)
+	defer cleanup()
+
+	allocation, err := AllocateFromIpam(path, "node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Gateway and excluded addresses are skipped
+	if allocation.Address != "172.16.14.4" || allocation.Netmask != "255.255.255.248" || allocation.Gateway != "172.16.14.1" {
+		t.Errorf("Unexpected allocation: %#v", allocation)
+	}
+
+	// Same address is returned to same machine
+	again, err := AllocateFromIpam(path, "node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Address != allocation.Address {
+		t.Errorf("Leased address should be reused: %s", again.Address)
+	}
+	if err := CheckIpamLease(path, allocation.Address, "node1"); err != nil {
+		t.Error(err)
+	}
+
+	// Next subnet is used when first subnet is full
+	var addresses []string
+	for i := 2; i <= 4; i++ {
+		a, err := AllocateFromIpam(path, fmt.Sprintf("node%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		addresses = append(addresses, a.Address)
+	}
+	expected := []string{"172.16.14.5", "172.16.14.6", "172.16.15.2"}
+	if fmt.Sprint(addresses) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected addresses: %v", addresses)
+	}
+	if _, err := AllocateFromIpam(path, "node5"); err == nil {
+		t.Error("Allocation should fail when all subnets are full")
+	}
+
+	if err := ReleaseFromIpam(path, allocation.Address, "node1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckIpamLease(path, allocation.Address, "node1"); err == nil {
+		t.Error("Released address should not be leased")
+	}
+	a, err := AllocateFromIpam(path, "node5")
+	if err != nil || a.Address != allocation.Address {
+		t.Errorf("Released address should be allocated again: %v %v", a, err)
+	}
+}
+
+func TestIpamPoolFileUnchanged(t *testing.T) {
+	content := "# Rack 1\n" + testIpamYaml
+	path, cleanup := createTestIpamFile(t, content)
+	defer cleanup()
+	if err := os.Chmod(path, 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	allocation, err := AllocateFromIpam(path, "node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != content {
+		t.Errorf("Pool file should not be changed:\n%s", bytes)
+	}
+	leases, err := ioutil.ReadFile(path + ipamLeasesSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(leases) != allocation.Address+": node1\n" {
+		t.Errorf("Unexpected leases file:\n%s", leases)
+	}
+}
+
+func TestIpamParallel(t *testing.T) {
+	path, cleanup := createTestIpamFile(t, "subnets:\n  - cidr: 10.0.0.0/24\n")
+	defer cleanup()
+	interval := ipamLockInterval
+	defer func() { ipamLockInterval = interval }()
+	ipamLockInterval = time.Millisecond
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	leased := map[string]string{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(owner string) {
+			defer wg.Done()
+			a, err := AllocateFromIpam(path, owner)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if other, ok := leased[a.Address]; ok {
+				t.Errorf("%s is allocated to %s and %s", a.Address, other, owner)
+			}
+			leased[a.Address] = owner
+		}(fmt.Sprintf("node%d", i))
+	}
+	wg.Wait()
+
+	f, err := LoadIpamFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Leases) != 20 {
+		t.Errorf("All leases should be saved: %v", f.Leases)
+	}
+}
+
+func TestIpamLockOfKilledProcess(t *testing.T) {
+	path, cleanup := createTestIpamFile(t, testIpamYaml)
+	defer cleanup()
+
+	// Lock file left by killed process is not locked
+	if err := ioutil.WriteFile(path+".lock", []byte("1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AllocateFromIpam(path, "node1"); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := lockFile(path+".lock", time.Second, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Unlock()
+	if _, err := lockFile(path+".lock", 10*time.Millisecond, time.Millisecond); err == nil {
+		t.Error("Locked file should not be locked again")
+	}
+}
+
+func TestIpamInvalidFile(t *testing.T) {
+	cases := []string{
+		"subnets: []",
+		"subnets:\n  - cidr: 172.16.14.0\n",
+		"subnets:\n  - cidr: 172.16.14.0/24\n    gateway: 10.0.0.1\n",
+		"subnets:\n  - cidr: 172.16.14.0/24\n    exclude: [172.16.14.1-x]\n",
+		"subnets:\n  - cidr: 172.16.14.0/24\n    netmask: 255.255.255.0\n",
+	}
+	for _, content := range cases {
+		path, cleanup := createTestIpamFile(t, content)
+		if _, err := LoadIpamFile(path); err == nil {
+			t.Errorf("Invalid IPAM file should be error: %q", content)
+		}
+		cleanup()
+	}
+}
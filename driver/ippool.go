@@ -0,0 +1,240 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+// Get IP address of new server from HPE OneView IPv4 subnet linked to ethernet network
+type IpPool struct {
+	Network string `yaml:"network"`         // Ethernet network name
+	Range   string `yaml:"range,omitempty"` // Range name. Any enabled range of subnet is used if empty
+}
+
+// IP address and network settings given to new server
+type IpAllocation struct {
+	Address    string   `json:"address"`
+	Netmask    string   `json:"netmask,omitempty"`
+	Gateway    string   `json:"gateway,omitempty"`
+	DnsServers []string `json:"dnsServers,omitempty"`
+	Domain     string   `json:"domain,omitempty"`
+	RangeUri   string   `json:"rangeUri,omitempty"` // HPE OneView IPv4 range which address is allocated from
+	IpamFile   string   `json:"ipamFile,omitempty"` // Local IPAM file which address is leased from
+}
+
+type ovIpv4Subnet struct {
+	Name       string   `json:"name"`
+	NetworkId  string   `json:"networkId"`
+	SubnetMask string   `json:"subnetmask"`
+	Gateway    string   `json:"gateway"`
+	DnsServers []string `json:"dnsServers"`
+	Domain     string   `json:"domain"`
+	RangeUris  []string `json:"rangeUris"`
+	URI        string   `json:"uri"`
+}
+
+type ovIdList struct {
+	Count  int      `json:"count,omitempty"`
+	IdList []string `json:"idList,omitempty"`
+}
+
+func (p *IpPool) IsEnabled() bool {
+	return p != nil && p.Network != ""
+}
+
+// Check IPv4 subnet and ranges linked to network
+func (o *Oneview) ValidateIpPool(ovc *ov.OVClient) error {
+	subnet, err := o.getIpv4Subnet(ovc)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	ranges, err := o.getIpv4Ranges(ovc, subnet)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if len(ranges) == 0 {
+		err := fmt.Errorf("No enabled IPv4 range for network %s", o.IpPool.Network)
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Allocate one IP address from IPv4 range of network
+func (o *Oneview) AllocateIp() (*IpAllocation, error) {
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	subnet, err := o.getIpv4Subnet(ovc)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	ranges, err := o.getIpv4Ranges(ovc, subnet)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	for _, r := range ranges {
+		var allocated ovIdList
+		if err := ovRestCall(ovc, rest.PUT, r.AllocatorUri.String(), ovIdList{Count: 1}, &allocated); err != nil {
+			log.Infof("IP address can not be allocated from %s: %v", r.Name, err)
+			continue
+		}
+		if len(allocated.IdList) == 0 {
+			log.Infof("No free IP address in %s", r.Name)
+			continue
+		}
+		allocation := &IpAllocation{
+			Address:    allocated.IdList[0],
+			Netmask:    subnet.SubnetMask,
+			Gateway:    subnet.Gateway,
+			DnsServers: subnet.DnsServers,
+			Domain:     subnet.Domain,
+			RangeUri:   r.URI.String(),
+		}
+		log.Infof("IP address %s is allocated from %s", allocation.Address, r.Name)
+		return allocation, nil
+	}
+
+	err = fmt.Errorf("No free IP address in subnet %s of network %s", subnet.NetworkId, o.IpPool.Network)
+	log.Error(Wrap(err))
+	return nil, err
+}
+
+// Return allocated IP address to IPv4 range
+func (o *Oneview) ReleaseIp(allocation *IpAllocation) error {
+	if allocation == nil || allocation.RangeUri == "" {
+		return nil
+	}
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Infof("Release IP address %s", allocation.Address)
+	body := ovIdList{IdList: []string{allocation.Address}}
+	if err := ovRestCall(ovc, rest.PUT, allocation.RangeUri+"/collector", body, nil); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Check IP address is still allocated in IPv4 range
+func (o *Oneview) CheckIp(allocation *IpAllocation) error {
+	if allocation == nil || allocation.RangeUri == "" {
+		err := fmt.Errorf("IP address is not allocated")
+		log.Error(Wrap(err))
+		return err
+	}
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	var fragments ov.FragmentsList
+	if err := ovRestCall(ovc, rest.GET, allocation.RangeUri+"/allocated-fragments", nil, &fragments); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	address := net.ParseIP(allocation.Address)
+	for _, fragment := range fragments.Members {
+		if ipInRange(address, fragment.StartAddress.String(), fragment.EndAddress.String()) {
+			return nil
+		}
+	}
+	err = fmt.Errorf("IP address %s is not allocated in %s", allocation.Address, allocation.RangeUri)
+	log.Error(Wrap(err))
+	return err
+}
+
+// Find IPv4 subnet associated with ethernet network
+func (o *Oneview) getIpv4Subnet(ovc *ov.OVClient) (*ovIpv4Subnet, error) {
+	networkName := o.IpPool.Network
+	network, err := ovc.GetEthernetNetworkByName(networkName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if network.Name != networkName {
+		err := fmt.Errorf("Ethernet network %s does not exist", networkName)
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if network.SubnetUri.IsNil() {
+		err := fmt.Errorf("Ethernet network %s is not associated with IPv4 subnet", networkName)
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	var subnet ovIpv4Subnet
+	if err := ovRestCall(ovc, rest.GET, network.SubnetUri.String(), nil, &subnet); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	log.Debugf("IPv4 subnet: %#v", subnet)
+	return &subnet, nil
+}
+
+// Enabled ranges of IPv4 subnet. Only named range is returned if range name is specified.
+func (o *Oneview) getIpv4Ranges(ovc *ov.OVClient, subnet *ovIpv4Subnet) ([]ov.Ipv4Range, error) {
+	var ranges []ov.Ipv4Range
+	for _, uri := range subnet.RangeUris {
+		var r ov.Ipv4Range
+		if err := ovRestCall(ovc, rest.GET, uri, nil, &r); err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+		if !r.Enabled || (o.IpPool.Range != "" && r.Name != o.IpPool.Range) {
+			continue
+		}
+		if r.URI.IsNil() {
+			r.URI = utils.NewNstring(uri)
+		}
+		if r.AllocatorUri.IsNil() {
+			r.AllocatorUri = utils.NewNstring(uri + "/allocator")
+		}
+		ranges = append(ranges, r)
+	}
+	if o.IpPool.Range != "" && len(ranges) == 0 {
+		err := fmt.Errorf("IPv4 range %s does not exist or is disabled in subnet %s", o.IpPool.Range, subnet.NetworkId)
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// Call HPE OneView REST API and decode response into result if it is not nil
+func ovRestCall(ovc *ov.OVClient, method rest.Method, uri string, body interface{}, result interface{}) error {
+	ovc.RefreshLogin()
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(method, uri, body)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+func ipInRange(ip net.IP, start, end string) bool {
+	ip, startIp, endIp := ip.To4(), net.ParseIP(start).To4(), net.ParseIP(end).To4()
+	if ip == nil || startIp == nil || endIp == nil {
+		return false
+	}
+	return bytes.Compare(ip, startIp) >= 0 && bytes.Compare(ip, endIp) <= 0
+}
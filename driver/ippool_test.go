@@ -0,0 +1,140 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Fake HPE OneView which has one IPv4 subnet with two ranges
+type testIpPoolServer struct {
+	sync.Mutex
+	allocated map[string]bool
+	next      int
+	server    *httptest.Server
+}
+
+func createTestIpPoolServer() *testIpPoolServer {
+	s := &testIpPoolServer{allocated: map[string]bool{}, next: 10}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		switch r.URL.Path {
+		case "/rest/version":
+			fmt.Fprint(w, `{"currentVersion":2400,"minimumVersion":120}`)
+		case "/rest/login-sessions":
+			fmt.Fprint(w, `{"sessionID":"ip-session"}`)
+		case "/rest/sessions/idle-timeout":
+			fmt.Fprint(w, `{"idleTimeout":86400000}`)
+		case "/rest/ethernet-networks":
+			fmt.Fprint(w, `{"total":1,"count":1,"members":[{"name":"deploy","subnetUri":"/rest/id-pools/ipv4/subnets/s1"}]}`)
+		case "/rest/id-pools/ipv4/subnets/s1":
+			fmt.Fprint(w, `{"networkId":"172.16.14.0","subnetmask":"255.255.255.0","gateway":"172.16.14.1","dnsServers":["172.16.14.2"],"domain":"example.com","rangeUris":["/rest/id-pools/ipv4/ranges/r1","/rest/id-pools/ipv4/ranges/r2"]}`)
+		case "/rest/id-pools/ipv4/ranges/r1":
+			fmt.Fprint(w, `{"name":"disabled","enabled":false,"uri":"/rest/id-pools/ipv4/ranges/r1"}`)
+		case "/rest/id-pools/ipv4/ranges/r2":
+			fmt.Fprint(w, `{"name":"nodes","enabled":true,"uri":"/rest/id-pools/ipv4/ranges/r2","allocatorUri":"/rest/id-pools/ipv4/ranges/r2/allocator"}`)
+		case "/rest/id-pools/ipv4/ranges/r2/allocator":
+			address := fmt.Sprintf("172.16.14.%d", s.next)
+			s.next++
+			s.allocated[address] = true
+			fmt.Fprintf(w, `{"count":1,"idList":["%s"]}`, address)
+		case "/rest/id-pools/ipv4/ranges/r2/collector":
+			var ids ovIdList
+			json.NewDecoder(r.Body).Decode(&ids)
+			for _, id := range ids.IdList {
+				delete(s.allocated, id)
+			}
+			fmt.Fprint(w, `{}`)
+		case "/rest/id-pools/ipv4/ranges/r2/allocated-fragments":
+			var members []string
+			for address := range s.allocated {
+				members = append(members, fmt.Sprintf(`{"startAddress":"%s","endAddress":"%s"}`, address, address))
+			}
+			fmt.Fprintf(w, `{"members":[%s]}`, strings.Join(members, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func TestIpPoolAllocateAndRelease(t *testing.T) {
+	s := createTestIpPoolServer()
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+	o.IpPool = &IpPool{Network: "deploy"}
+
+	allocation, err := o.AllocateIp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocation.Address != "172.16.14.10" || allocation.Netmask != "255.255.255.0" || allocation.Gateway != "172.16.14.1" {
+		t.Errorf("Unexpected allocation: %#v", allocation)
+	}
+	if allocation.RangeUri != "/rest/id-pools/ipv4/ranges/r2" {
+		t.Errorf("Address should be allocated from enabled range: %s", allocation.RangeUri)
+	}
+	if err := o.CheckIp(allocation); err != nil {
+		t.Error(err)
+	}
+
+	if err := o.ReleaseIp(allocation); err != nil {
+		t.Fatal(err)
+	}
+	if s.allocated[allocation.Address] {
+		t.Errorf("%s should be released", allocation.Address)
+	}
+	if err := o.CheckIp(allocation); err == nil {
+		t.Error("Released address should not be allocated")
+	}
+}
+
+func TestIpPoolRangeName(t *testing.T) {
+	s := createTestIpPoolServer()
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+
+	o.IpPool = &IpPool{Network: "deploy", Range: "disabled"}
+	if _, err := o.AllocateIp(); err == nil {
+		t.Error("Disabled range should not be used")
+	}
+	o.IpPool = &IpPool{Network: "deploy", Range: "nodes"}
+	if _, err := o.AllocateIp(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIpPoolIpInRange(t *testing.T) {
+	ip := net.ParseIP("10.0.0.5")
+	if !ipInRange(ip, "10.0.0.1", "10.0.0.10") {
+		t.Error("10.0.0.5 should be in range")
+	}
+	if ipInRange(ip, "10.0.0.6", "10.0.0.10") {
+		t.Error("10.0.0.5 should not be in range")
+	}
+}
+
+func TestIpPoolSetAllocation(t *testing.T) {
+	d := NewDriver("node1", "")
+	d.HpeConfig = &HpeConfig{
+		Oneview: &Oneview{},
+		Server:  &Server{KsBaseUrl: "http://web/ks"},
+	}
+	d.setIpAllocation(&IpAllocation{Address: "172.16.14.11"})
+
+	if ip, err := d.GetIP(); err != nil || ip != "172.16.14.11" {
+		t.Errorf("GetIP should return allocated address: %s %v", ip, err)
+	}
+	if url, err := d.GetURL(); err != nil || url != "tcp://172.16.14.11:2376" {
+		t.Errorf("GetURL should use allocated address: %s %v", url, err)
+	}
+	if d.HpeConfig.Server.KsUrl != "http://web/ks/172.16.14.11.iso" {
+		t.Errorf("Kickstart URL should be named after allocated address: %s", d.HpeConfig.Server.KsUrl)
+	}
+}
@@ -0,0 +1,673 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	isoSectorSize      = 2048
+	isoSystemAreaSize  = 16 * isoSectorSize
+	isoMaxLabel        = 32
+	isoMaxName         = 30 // Level 2 file name without version
+	isoDirRecordSize   = 33
+	isoPxSize          = 36
+	isoPadSectors      = 150 // Trailing padding like mkisofs. Some readers need minimum image size
+	isoPvdSector       = 16
+	isoBootSystemId    = "EL TORITO SPECIFICATION"
+	isoBootPlatformX86 = 0x00
+	isoBootPlatformEfi = 0xef
+	isoBootNoEmulation = 0x00
+)
+
+// Characters which can not be used in ISO9660 names
+var isoNameReplacer = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// ISO9660 image with Rock Ridge names.
+// Files are read when image is written, so large files are not kept in memory.
+type isoImage struct {
+	label   string
+	root    *isoDir
+	created time.Time
+	dirs    []*isoDir  // Path table order
+	files   []*isoFile // Data order
+	sectors uint32
+	boot    []*isoBootEntry // El Torito boot images. First one is default entry.
+	catalog *isoFile
+
+	pathTableSize int
+	pathTableL    uint32
+	pathTableM    uint32
+}
+
+type isoDir struct {
+	name    string // Rock Ridge name
+	isoName string
+	parent  *isoDir
+	dirs    []*isoDir
+	files   []*isoFile
+	number  int // Path table number
+	lba     uint32
+	size    uint32
+}
+
+type isoFile struct {
+	name    string // Rock Ridge name
+	isoName string
+	size    int64
+	open    func() (io.ReadCloser, error)
+	lba     uint32
+	target  string // Target of symbolic link. File has no data if set.
+}
+
+// El Torito boot entry
+type isoBootEntry struct {
+	platform      byte
+	media         byte
+	loadSegment   uint16
+	systemType    byte
+	sectorCount   uint16 // Count of 512 bytes virtual sectors loaded by BIOS
+	path          string
+	bootInfoTable bool // Patch boot info table like mkisofs -boot-info-table
+	file          *isoFile
+}
+
+// Directory record which is written in parent directory
+type isoRecord struct {
+	isoName string
+	name    string
+	lba     uint32
+	size    uint32
+	isDir   bool
+	target  string
+}
+
+func newIsoImage(label string) *isoImage {
+	return &isoImage{
+		label:   label,
+		root:    &isoDir{},
+		created: time.Now().UTC(),
+	}
+}
+
+// Add file which is read by open when image is written
+func (img *isoImage) AddFile(path string, size int64, open func() (io.ReadCloser, error)) error {
+	if size > 0xffffffff {
+		return fmt.Errorf("%s is too large for ISO9660 image", path)
+	}
+	return img.addFile(path, &isoFile{size: size, open: open})
+}
+
+// Add symbolic link which is written in Rock Ridge SL entry
+func (img *isoImage) AddSymlink(path, target string) error {
+	if target == "" {
+		return fmt.Errorf("Symbolic link %s has no target", path)
+	}
+	return img.addFile(path, &isoFile{target: target})
+}
+
+func (img *isoImage) addFile(path string, file *isoFile) error {
+	elements := strings.Split(strings.Trim(path, "/"), "/")
+	dir := img.root
+	for _, name := range elements[:len(elements)-1] {
+		dir = dir.subdir(name)
+	}
+	name := elements[len(elements)-1]
+	if name == "" {
+		return fmt.Errorf("Invalid file path %s", path)
+	}
+	for _, f := range dir.files {
+		if f.name == name {
+			return fmt.Errorf("%s already exists in ISO9660 image", path)
+		}
+	}
+	file.name = name
+	dir.files = append(dir.files, file)
+	return nil
+}
+
+// Add file from memory
+func (img *isoImage) AddBytes(path string, data []byte) error {
+	return img.AddFile(path, int64(len(data)), func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	})
+}
+
+// Create directory even if it has no file
+func (img *isoImage) AddDir(path string) {
+	dir := img.root
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name != "" {
+			dir = dir.subdir(name)
+		}
+	}
+}
+
+// Find file added in image
+func (img *isoImage) file(path string) *isoFile {
+	elements := strings.Split(strings.Trim(path, "/"), "/")
+	dir := img.root
+	for _, name := range elements[:len(elements)-1] {
+		var found *isoDir
+		for _, sub := range dir.dirs {
+			if sub.name == name {
+				found = sub
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		dir = found
+	}
+	for _, f := range dir.files {
+		if f.name == elements[len(elements)-1] {
+			return f
+		}
+	}
+	return nil
+}
+
+// Make image bootable with El Torito boot images which are already added.
+// Boot catalog is created in catalogPath.
+func (img *isoImage) SetBoot(catalogPath string, entries []*isoBootEntry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("No boot image is specified")
+	}
+	if len(entries) > (isoSectorSize-64)/64+1 {
+		return fmt.Errorf("Too many boot images: %d", len(entries))
+	}
+	for _, e := range entries {
+		e.file = img.file(e.path)
+		if e.file == nil {
+			return fmt.Errorf("Boot image %s is not found in ISO9660 image", e.path)
+		}
+		if e.bootInfoTable {
+			if e.file.size < 64 {
+				return fmt.Errorf("Boot image %s is too small for boot info table", e.path)
+			}
+			e.file.open = bootInfoTableReader(e.file)
+		}
+	}
+	if err := img.AddFile(catalogPath, isoSectorSize, func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(img.bootCatalog())), nil
+	}); err != nil {
+		return err
+	}
+	img.catalog = img.file(catalogPath)
+	img.boot = entries
+	return nil
+}
+
+// Boot info table has addresses which are decided when image is written
+func bootInfoTableReader(f *isoFile) func() (io.ReadCloser, error) {
+	open := f.open
+	return func() (io.ReadCloser, error) {
+		r, err := open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(io.LimitReader(r, f.size))
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint32(data[8:12], isoPvdSector)
+		binary.LittleEndian.PutUint32(data[12:16], f.lba)
+		binary.LittleEndian.PutUint32(data[16:20], uint32(f.size))
+		var sum uint32
+		padded := append(data[64:len(data):len(data)], make([]byte, (4-len(data)%4)%4)...)
+		for i := 0; i < len(padded); i += 4 {
+			sum += binary.LittleEndian.Uint32(padded[i : i+4])
+		}
+		binary.LittleEndian.PutUint32(data[20:24], sum)
+		copy(data[24:64], make([]byte, 40))
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	}
+}
+
+// Boot catalog with validation entry, default entry and one section for each other entry
+func (img *isoImage) bootCatalog() []byte {
+	catalog := make([]byte, isoSectorSize)
+	validation := catalog[0:32]
+	validation[0] = 1
+	validation[1] = img.boot[0].platform
+	copy(validation[4:28], "DOCKER-MACHINE-DRIVER-OV")
+	validation[30], validation[31] = 0x55, 0xaa
+	var sum uint16
+	for i := 0; i < 32; i += 2 {
+		sum += binary.LittleEndian.Uint16(validation[i : i+2])
+	}
+	binary.LittleEndian.PutUint16(validation[28:30], -sum)
+
+	img.boot[0].write(catalog[32:64])
+	offset := 64
+	for i, e := range img.boot[1:] {
+		header := catalog[offset : offset+32]
+		header[0] = 0x90
+		if i == len(img.boot)-2 {
+			header[0] = 0x91
+		}
+		header[1] = e.platform
+		binary.LittleEndian.PutUint16(header[2:4], 1)
+		e.write(catalog[offset+32 : offset+64])
+		offset += 64
+	}
+	return catalog
+}
+
+func (e *isoBootEntry) write(entry []byte) {
+	entry[0] = 0x88
+	entry[1] = e.media
+	binary.LittleEndian.PutUint16(entry[2:4], e.loadSegment)
+	entry[4] = e.systemType
+	binary.LittleEndian.PutUint16(entry[6:8], e.sectorCount)
+	binary.LittleEndian.PutUint32(entry[8:12], e.file.lba)
+}
+
+func (d *isoDir) subdir(name string) *isoDir {
+	for _, sub := range d.dirs {
+		if sub.name == name {
+			return sub
+		}
+	}
+	sub := &isoDir{name: name, parent: d}
+	d.dirs = append(d.dirs, sub)
+	return sub
+}
+
+// Decide ISO9660 names and sector addresses
+func (img *isoImage) layout() error {
+	if len(img.label) > isoMaxLabel {
+		return fmt.Errorf("Volume label %s is longer than %d characters", img.label, isoMaxLabel)
+	}
+
+	// Breadth first order is path table order
+	img.dirs = []*isoDir{img.root}
+	for i := 0; i < len(img.dirs); i++ {
+		dir := img.dirs[i]
+		dir.number = i + 1
+		if err := dir.assignNames(); err != nil {
+			return err
+		}
+		img.dirs = append(img.dirs, dir.dirs...)
+	}
+
+	// Volume descriptors
+	lba := uint32(isoPvdSector + len(img.descriptors()))
+	img.pathTableSize = len(img.pathTable(binary.LittleEndian))
+	img.pathTableL = lba
+	img.pathTableM = img.pathTableL + isoSectors(int64(img.pathTableSize))
+	lba = img.pathTableM + isoSectors(int64(img.pathTableSize))
+
+	for _, dir := range img.dirs {
+		records, err := dir.records()
+		if err != nil {
+			return err
+		}
+		dir.size = isoSectorSize * isoSectors(int64(len(packIsoRecords(records))))
+		dir.lba = lba
+		lba += dir.size / isoSectorSize
+	}
+
+	img.files = nil
+	for _, dir := range img.dirs {
+		for _, f := range dir.files {
+			if f.target != "" {
+				continue
+			}
+			f.lba = lba
+			lba += isoSectors(f.size)
+			img.files = append(img.files, f)
+		}
+	}
+	img.sectors = lba + isoPadSectors
+	return nil
+}
+
+// Give unique ISO9660 names to children and sort them
+func (d *isoDir) assignNames() error {
+	used := map[string]bool{}
+	unique := func(base, ext string, max int) string {
+		for i := 0; ; i++ {
+			b := base
+			if i > 0 {
+				suffix := fmt.Sprintf("_%d", i)
+				if len(b)+len(suffix) > max {
+					b = b[:max-len(suffix)]
+				}
+				b += suffix
+			}
+			name := b + ext
+			if !used[name] {
+				used[name] = true
+				return name
+			}
+		}
+	}
+
+	for _, sub := range d.dirs {
+		base := isoNameReplacer.ReplaceAllString(strings.ToUpper(sub.name), "_")
+		if len(base) > isoMaxName+1 {
+			base = base[:isoMaxName+1]
+		}
+		sub.isoName = unique(base, "", isoMaxName+1)
+	}
+	for _, f := range d.files {
+		base, ext := f.name, ""
+		if i := strings.LastIndex(f.name, "."); i > 0 {
+			base, ext = f.name[:i], f.name[i+1:]
+		}
+		base = isoNameReplacer.ReplaceAllString(strings.ToUpper(base), "_")
+		ext = isoNameReplacer.ReplaceAllString(strings.ToUpper(ext), "_")
+		if len(ext) > 8 {
+			ext = ext[:8]
+		}
+		max := isoMaxName - 1 - len(ext)
+		if len(base) > max {
+			base = base[:max]
+		}
+		f.isoName = unique(base, "."+ext, max) + ";1"
+	}
+	sort.Slice(d.dirs, func(i, j int) bool { return d.dirs[i].isoName < d.dirs[j].isoName })
+	sort.Slice(d.files, func(i, j int) bool { return d.files[i].isoName < d.files[j].isoName })
+	return nil
+}
+
+// Records of directory including "." and ".."
+func (d *isoDir) records() ([][]byte, error) {
+	parent := d.parent
+	if parent == nil {
+		parent = d
+	}
+	children := []isoRecord{}
+	for _, sub := range d.dirs {
+		children = append(children, isoRecord{isoName: sub.isoName, name: sub.name, lba: sub.lba, size: sub.size, isDir: true})
+	}
+	for _, f := range d.files {
+		children = append(children, isoRecord{isoName: f.isoName, name: f.name, lba: f.lba, size: uint32(f.size), target: f.target})
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].isoName < children[j].isoName })
+
+	self := isoDirRecord("\x00", d.lba, d.size, true, rockRidgeEntries("", "", true, d.parent == nil))
+	up := isoDirRecord("\x01", parent.lba, parent.size, true, rockRidgeEntries("", "", true, false))
+	records := [][]byte{self, up}
+	for _, c := range children {
+		su := rockRidgeEntries(c.name, c.target, c.isDir, false)
+		if isoDirRecordSize+len(c.isoName)+1+len(su) > 255 {
+			return nil, fmt.Errorf("File name %s is too long for ISO9660 image", c.name)
+		}
+		records = append(records, isoDirRecord(c.isoName, c.lba, c.size, c.isDir, su))
+	}
+	return records, nil
+}
+
+// Records can not cross sector boundary
+func packIsoRecords(records [][]byte) []byte {
+	var buf []byte
+	for _, r := range records {
+		if rest := isoSectorSize - len(buf)%isoSectorSize; rest < len(r) {
+			buf = append(buf, make([]byte, rest)...)
+		}
+		buf = append(buf, r...)
+	}
+	return buf
+}
+
+// System use entries of Rock Ridge
+func rockRidgeEntries(name, target string, isDir, isRoot bool) []byte {
+	var su []byte
+	if isRoot {
+		// SUSP indicator and extension reference
+		su = append(su, 'S', 'P', 7, 1, 0xbe, 0xef, 0)
+		id, des, src := "RRIP_1991A", "ROCK RIDGE INTERCHANGE PROTOCOL", ""
+		su = append(su, 'E', 'R', byte(8+len(id)+len(des)+len(src)), 1, byte(len(id)), byte(len(des)), byte(len(src)), 1)
+		su = append(su, id+des+src...)
+	}
+	mode, links := uint32(0100444), uint32(1)
+	if isDir {
+		mode, links = 040555, 2
+	}
+	if target != "" {
+		mode = 0120777
+	}
+	px := []byte{'P', 'X', isoPxSize, 1}
+	px = append(px, bothEndian32(mode)...)
+	px = append(px, bothEndian32(links)...)
+	px = append(px, bothEndian32(0)...)
+	px = append(px, bothEndian32(0)...)
+	su = append(su, px...)
+	if name != "" {
+		su = append(su, 'N', 'M', byte(5+len(name)), 1, 0)
+		su = append(su, name...)
+	}
+	if target != "" {
+		su = append(su, rockRidgeSymlink(target)...)
+	}
+	return su
+}
+
+// SL entry with component records of symbolic link target
+func rockRidgeSymlink(target string) []byte {
+	var components []byte
+	if strings.HasPrefix(target, "/") {
+		components = append(components, 0x08, 0)
+	}
+	for _, c := range strings.Split(target, "/") {
+		switch c {
+		case "":
+		case ".":
+			components = append(components, 0x02, 0)
+		case "..":
+			components = append(components, 0x04, 0)
+		default:
+			components = append(components, 0, byte(len(c)))
+			components = append(components, c...)
+		}
+	}
+	sl := []byte{'S', 'L', byte(5 + len(components)), 1, 0}
+	return append(sl, components...)
+}
+
+func isoDirRecord(isoName string, lba, size uint32, isDir bool, systemUse []byte) []byte {
+	length := isoDirRecordSize + len(isoName)
+	if length%2 != 0 {
+		length++
+	}
+	r := make([]byte, length, length+len(systemUse)+1)
+	copy(r[2:10], bothEndian32(lba))
+	copy(r[10:18], bothEndian32(size))
+	copy(r[18:25], isoRecordTime(time.Now().UTC()))
+	if isDir {
+		r[25] = 2
+	}
+	copy(r[28:32], bothEndian16(1))
+	r[32] = byte(len(isoName))
+	copy(r[33:], isoName)
+	r = append(r, systemUse...)
+	if len(r)%2 != 0 {
+		r = append(r, 0)
+	}
+	r[0] = byte(len(r))
+	return r
+}
+
+func (img *isoImage) pathTable(order binary.ByteOrder) []byte {
+	var buf []byte
+	for _, dir := range img.dirs {
+		id := dir.isoName
+		parent := 1
+		if dir.parent == nil {
+			id = "\x00"
+		} else {
+			parent = dir.parent.number
+		}
+		entry := make([]byte, 8+len(id))
+		entry[0] = byte(len(id))
+		order.PutUint32(entry[2:6], dir.lba)
+		order.PutUint16(entry[6:8], uint16(parent))
+		copy(entry[8:], id)
+		if len(id)%2 != 0 {
+			entry = append(entry, 0)
+		}
+		buf = append(buf, entry...)
+	}
+	return buf
+}
+
+// Volume descriptors from sector 16
+func (img *isoImage) descriptors() [][]byte {
+	pvd := make([]byte, isoSectorSize)
+	pvd[0] = 1
+	copy(pvd[1:6], "CD001")
+	pvd[6] = 1
+	copy(pvd[8:40], padString("LINUX", 32))
+	copy(pvd[40:72], padString(img.label, 32))
+	copy(pvd[80:88], bothEndian32(img.sectors))
+	copy(pvd[120:124], bothEndian16(1))
+	copy(pvd[124:128], bothEndian16(1))
+	copy(pvd[128:132], bothEndian16(isoSectorSize))
+	copy(pvd[132:140], bothEndian32(uint32(img.pathTableSize)))
+	binary.LittleEndian.PutUint32(pvd[140:144], img.pathTableL)
+	binary.BigEndian.PutUint32(pvd[148:152], img.pathTableM)
+	copy(pvd[156:190], isoDirRecord("\x00", img.root.lba, img.root.size, true, nil))
+	copy(pvd[190:318], padString("", 128))
+	copy(pvd[318:446], padString("", 128))
+	copy(pvd[446:574], padString("", 128))
+	copy(pvd[574:702], padString("DOCKER-MACHINE-DRIVER-OV", 128))
+	copy(pvd[702:813], padString("", 111))
+	created := isoVolumeTime(img.created)
+	copy(pvd[813:830], created)
+	copy(pvd[830:847], created)
+	copy(pvd[847:864], isoVolumeTime(time.Time{}))
+	copy(pvd[864:881], created)
+	pvd[881] = 1
+
+	descriptors := [][]byte{pvd}
+	if img.catalog != nil {
+		bootRecord := make([]byte, isoSectorSize)
+		bootRecord[0] = 0
+		copy(bootRecord[1:6], "CD001")
+		bootRecord[6] = 1
+		copy(bootRecord[7:39], isoBootSystemId)
+		binary.LittleEndian.PutUint32(bootRecord[71:75], img.catalog.lba)
+		descriptors = append(descriptors, bootRecord)
+	}
+
+	terminator := make([]byte, isoSectorSize)
+	terminator[0] = 255
+	copy(terminator[1:6], "CD001")
+	terminator[6] = 1
+	return append(descriptors, terminator)
+}
+
+// Write whole image
+func (img *isoImage) WriteTo(w io.Writer) (int64, error) {
+	if err := img.layout(); err != nil {
+		return 0, err
+	}
+	var written int64
+	write := func(data []byte) error {
+		n, err := w.Write(data)
+		written += int64(n)
+		return err
+	}
+	pad := func(size int64) error {
+		if rest := size % isoSectorSize; rest != 0 {
+			return write(make([]byte, isoSectorSize-rest))
+		}
+		return nil
+	}
+
+	if err := write(make([]byte, isoSystemAreaSize)); err != nil {
+		return written, err
+	}
+	pathTableL := img.pathTable(binary.LittleEndian)
+	pathTableM := img.pathTable(binary.BigEndian)
+	for _, descriptor := range img.descriptors() {
+		if err := write(descriptor); err != nil {
+			return written, err
+		}
+	}
+	for _, table := range [][]byte{pathTableL, pathTableM} {
+		if err := write(table); err != nil {
+			return written, err
+		}
+		if err := pad(int64(len(table))); err != nil {
+			return written, err
+		}
+	}
+	for _, dir := range img.dirs {
+		records, err := dir.records()
+		if err != nil {
+			return written, err
+		}
+		data := packIsoRecords(records)
+		if err := write(data); err != nil {
+			return written, err
+		}
+		if err := pad(int64(len(data))); err != nil {
+			return written, err
+		}
+	}
+	for _, f := range img.files {
+		r, err := f.open()
+		if err != nil {
+			return written, err
+		}
+		n, err := io.CopyN(w, r, f.size)
+		written += n
+		r.Close()
+		if err != nil {
+			return written, fmt.Errorf("Could not read %s: %v", f.name, err)
+		}
+		if err := pad(f.size); err != nil {
+			return written, err
+		}
+	}
+	if err := write(make([]byte, isoPadSectors*isoSectorSize)); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+func isoSectors(size int64) uint32 {
+	return uint32((size + isoSectorSize - 1) / isoSectorSize)
+}
+
+func bothEndian16(v uint16) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint16(b[0:2], v)
+	binary.BigEndian.PutUint16(b[2:4], v)
+	return b
+}
+
+func bothEndian32(v uint32) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint32(b[0:4], v)
+	binary.BigEndian.PutUint32(b[4:8], v)
+	return b
+}
+
+func padString(s string, n int) []byte {
+	b := bytes.Repeat([]byte{' '}, n)
+	copy(b, s)
+	return b
+}
+
+func isoRecordTime(t time.Time) []byte {
+	return []byte{byte(t.Year() - 1900), byte(t.Month()), byte(t.Day()), byte(t.Hour()), byte(t.Minute()), byte(t.Second()), 0}
+}
+
+func isoVolumeTime(t time.Time) []byte {
+	if t.IsZero() {
+		return append([]byte("0000000000000000"), 0)
+	}
+	return append([]byte(t.Format("20060102150405")+"00"), 0)
+}
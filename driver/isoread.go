@@ -0,0 +1,347 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+// Directory for boot images which are not in file tree of source image
+const hiddenBootDir = "eltorito"
+
+// Existing ISO9660 image read with Rock Ridge names
+type isoSource struct {
+	file        *os.File
+	label       string
+	dirs        []string
+	files       []*isoSourceFile
+	symlinks    []*isoSourceSymlink
+	boot        []*isoBootEntry // Boot entries with path of source image
+	catalogLba  uint32
+	catalogPath string
+	rockRidge   bool
+	suspSkip    int
+}
+
+type isoSourceFile struct {
+	path string
+	lba  uint32
+	size uint32
+}
+
+type isoSourceSymlink struct {
+	path   string
+	target string
+}
+
+func openIsoSource(filePath string) (*isoSource, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	src := &isoSource{file: f}
+	if err := src.read(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("Could not read %s as ISO9660 image: %v", filePath, err)
+	}
+	return src, nil
+}
+
+func (src *isoSource) Close() error {
+	return src.file.Close()
+}
+
+func (src *isoSource) readAt(lba uint32, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := src.file.ReadAt(data, int64(lba)*isoSectorSize); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Reader of file data in source image
+func (src *isoSource) open(f *isoSourceFile) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(io.NewSectionReader(src.file, int64(f.lba)*isoSectorSize, int64(f.size))), nil
+	}
+}
+
+func (src *isoSource) read() error {
+	var rootLba, rootSize uint32
+	for sector := uint32(isoPvdSector); ; sector++ {
+		descriptor, err := src.readAt(sector, isoSectorSize)
+		if err != nil {
+			return err
+		}
+		if string(descriptor[1:6]) != "CD001" {
+			return fmt.Errorf("Volume descriptor is not found at sector %d", sector)
+		}
+		switch descriptor[0] {
+		case 0:
+			if strings.TrimRight(string(descriptor[7:39]), "\x00") == isoBootSystemId {
+				src.catalogLba = binary.LittleEndian.Uint32(descriptor[71:75])
+			}
+		case 1:
+			src.label = strings.TrimRight(string(descriptor[40:72]), " ")
+			rootLba = binary.LittleEndian.Uint32(descriptor[156+2 : 156+6])
+			rootSize = binary.LittleEndian.Uint32(descriptor[156+10 : 156+14])
+		}
+		if descriptor[0] == 255 {
+			break
+		}
+	}
+	if rootLba == 0 {
+		return fmt.Errorf("Primary volume descriptor is not found")
+	}
+
+	// Rock Ridge is used if "." of root directory has SUSP indicator
+	root, err := src.readAt(rootLba, isoSectorSize)
+	if err != nil {
+		return err
+	}
+	if su := isoSystemUse(root[:root[0]]); len(su) >= 7 && bytes.Equal(su[:6], []byte{'S', 'P', 7, 1, 0xbe, 0xef}) {
+		src.rockRidge = true
+		src.suspSkip = int(su[6])
+	}
+
+	if err := src.readDir("", rootLba, rootSize, 0); err != nil {
+		return err
+	}
+	if src.catalogLba != 0 {
+		if err := src.readBootCatalog(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read directory recursively
+func (src *isoSource) readDir(dirPath string, lba, size uint32, depth int) error {
+	if depth > 64 {
+		return fmt.Errorf("Directory %s is too deep", dirPath)
+	}
+	data, err := src.readAt(lba, int(size))
+	if err != nil {
+		return err
+	}
+	for pos := 0; pos < len(data); {
+		length := int(data[pos])
+		if length == 0 {
+			// Rest of sector is padding
+			pos = (pos/isoSectorSize + 1) * isoSectorSize
+			continue
+		}
+		if pos+length > len(data) || length < isoDirRecordSize+1 {
+			return fmt.Errorf("Broken directory record in %s", dirPath)
+		}
+		record := data[pos : pos+length]
+		pos += length
+
+		nameLen := int(record[32])
+		if isoDirRecordSize+nameLen > length {
+			return fmt.Errorf("Broken directory record in %s", dirPath)
+		}
+		isoName := string(record[33 : 33+nameLen])
+		if isoName == "\x00" || isoName == "\x01" {
+			continue
+		}
+		flags := record[25]
+		if flags&0x80 != 0 {
+			return fmt.Errorf("Multi-extent file %s is not supported", path.Join(dirPath, isoName))
+		}
+		name, target, symlink, err := src.rockRidgeName(record)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = strings.TrimSuffix(strings.Split(isoName, ";")[0], ".")
+		}
+		childPath := path.Join(dirPath, name)
+		if symlink {
+			if target == "" {
+				return fmt.Errorf("Broken symbolic link %s", childPath)
+			}
+			src.symlinks = append(src.symlinks, &isoSourceSymlink{path: childPath, target: target})
+			continue
+		}
+		childLba := binary.LittleEndian.Uint32(record[2:6])
+		childSize := binary.LittleEndian.Uint32(record[10:14])
+		if flags&0x02 != 0 {
+			src.dirs = append(src.dirs, childPath)
+			if err := src.readDir(childPath, childLba, childSize, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		src.files = append(src.files, &isoSourceFile{path: childPath, lba: childLba, size: childSize})
+		if childLba == src.catalogLba && src.catalogLba != 0 {
+			src.catalogPath = childPath
+		}
+	}
+	return nil
+}
+
+// Name in NM entries and target in SL entries if record is symbolic link
+func (src *isoSource) rockRidgeName(record []byte) (string, string, bool, error) {
+	if !src.rockRidge {
+		return "", "", false, nil
+	}
+	su := isoSystemUse(record)
+	if len(su) < src.suspSkip {
+		return "", "", false, nil
+	}
+	su = su[src.suspSkip:]
+	var name, components []byte
+	symlink := false
+	for continuations := 0; ; continuations++ {
+		var next []byte
+		for len(su) >= 4 && su[2] >= 4 && int(su[2]) <= len(su) {
+			entry := su[:su[2]]
+			su = su[su[2]:]
+			switch string(entry[:2]) {
+			case "NM":
+				if len(entry) > 5 && entry[4]&0x06 == 0 {
+					name = append(name, entry[5:]...)
+				}
+			case "SL":
+				// Component records may continue in next SL entry
+				symlink = true
+				if len(entry) > 5 {
+					components = append(components, entry[5:]...)
+				}
+			case "CE":
+				if len(entry) >= 28 {
+					block := binary.LittleEndian.Uint32(entry[4:8])
+					offset := binary.LittleEndian.Uint32(entry[12:16])
+					size := binary.LittleEndian.Uint32(entry[20:24])
+					area, err := src.readAt(block, int(offset+size))
+					if err != nil {
+						return "", "", false, err
+					}
+					next = area[offset:]
+				}
+			case "ST":
+				su = nil
+			}
+		}
+		if next == nil || continuations > 16 {
+			break
+		}
+		su = next
+	}
+	return string(name), rockRidgeSymlinkTarget(components), symlink, nil
+}
+
+// Join component records of SL entries into symbolic link target
+func rockRidgeSymlinkTarget(components []byte) string {
+	var target string
+	continued := false
+	for len(components) >= 2 && 2+int(components[1]) <= len(components) {
+		flags, content := components[0], string(components[2:2+components[1]])
+		components = components[2+components[1]:]
+		switch {
+		case flags&0x08 != 0:
+			target, continued = "/", false
+			continue
+		case flags&0x02 != 0:
+			content = "."
+		case flags&0x04 != 0:
+			content = ".."
+		}
+		if !continued && target != "" && !strings.HasSuffix(target, "/") {
+			target += "/"
+		}
+		target += content
+		continued = flags&0x01 != 0
+	}
+	return target
+}
+
+// Boot entries in El Torito boot catalog
+func (src *isoSource) readBootCatalog() error {
+	catalog, err := src.readAt(src.catalogLba, isoSectorSize)
+	if err != nil {
+		return err
+	}
+	if catalog[0] != 1 || catalog[30] != 0x55 || catalog[31] != 0xaa {
+		return fmt.Errorf("Broken El Torito boot catalog")
+	}
+	src.addBootEntry(catalog[1], catalog[32:64])
+
+	for offset := 64; offset+32 <= len(catalog); {
+		header := catalog[offset : offset+32]
+		if header[0] != 0x90 && header[0] != 0x91 {
+			break
+		}
+		platform := header[1]
+		count := int(binary.LittleEndian.Uint16(header[2:4]))
+		offset += 32
+		for i := 0; i < count && offset+32 <= len(catalog); offset += 32 {
+			entry := catalog[offset : offset+32]
+			// Extension entries follow section entry
+			if entry[0] == 0x44 {
+				continue
+			}
+			src.addBootEntry(platform, entry)
+			i++
+		}
+		if header[0] == 0x91 {
+			break
+		}
+	}
+	return nil
+}
+
+func (src *isoSource) addBootEntry(platform byte, entry []byte) {
+	if entry[0] != 0x88 {
+		return
+	}
+	lba := binary.LittleEndian.Uint32(entry[8:12])
+	e := &isoBootEntry{
+		platform:    platform,
+		media:       entry[1],
+		loadSegment: binary.LittleEndian.Uint16(entry[2:4]),
+		systemType:  entry[4],
+		sectorCount: binary.LittleEndian.Uint16(entry[6:8]),
+	}
+	for _, f := range src.files {
+		if f.lba == lba && f.size > 0 {
+			e.path = f.path
+		}
+	}
+	if e.path == "" {
+		// Boot image out of file tree, such as appended EFI partition, is kept as file
+		if e.sectorCount == 0 {
+			log.Warnf("Boot image at sector %d is not found in file tree. It is skipped", lba)
+			return
+		}
+		e.path = fmt.Sprintf("%s/boot%d.img", hiddenBootDir, len(src.boot))
+		src.files = append(src.files, &isoSourceFile{path: e.path, lba: lba, size: uint32(e.sectorCount) * 512})
+		log.Infof("Boot image at sector %d is not found in file tree. It is written in %s", lba, e.path)
+	}
+	// mkisofs -boot-info-table writes PVD and file address at offset 8
+	if header, err := src.readAt(lba, 16); err == nil && e.media == isoBootNoEmulation &&
+		binary.LittleEndian.Uint32(header[8:12]) == isoPvdSector && binary.LittleEndian.Uint32(header[12:16]) == lba {
+		e.bootInfoTable = true
+	}
+	src.boot = append(src.boot, e)
+}
+
+// System use area after file identifier
+func isoSystemUse(record []byte) []byte {
+	start := isoDirRecordSize + int(record[32])
+	if start%2 != 0 {
+		start++
+	}
+	if start >= len(record) {
+		return nil
+	}
+	return record[start:]
+}
@@ -0,0 +1,343 @@
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	defaultKsLabel = "ov-ks"
+	ksFormatIso    = "iso"
+	ksFormatFat    = "fat"
+	ksFileName     = "ks.cfg"
+)
+
+// Values passed to kickstart template
+type KickstartParams struct {
+	Hostname         string
+	Fqdn             string
+	Address          string
+	Netmask          string
+	Gateway          string
+	DnsServers       []string
+	Domain           string
+	PrefixLength     int // Netmask as CIDR prefix length
+	RootPasswordHash string
+	SshPublicKey     string
+}
+
+// Image which kickstart file is packed into
+type kickstartImage interface {
+	AddBytes(path string, data []byte) error
+	WriteTo(w io.Writer) (int64, error)
+}
+
+// Used when kickstart template is not specified
+const defaultKickstartTemplate = `# Generated by docker-machine-driver-ov
+text
+keyboard --vckeymap=us --xlayouts='us'
+lang en_US.UTF-8
+timezone UTC --isUtc
+{{- if .RootPasswordHash}}
+rootpw --iscrypted {{.RootPasswordHash}}
+{{- else}}
+rootpw --lock
+{{- end}}
+{{- if .SshPublicKey}}
+sshkey --username=root "{{.SshPublicKey}}"
+{{- end}}
+network --bootproto=static --device=link --ip={{.Address}} --netmask={{.Netmask}}{{if .Gateway}} --gateway={{.Gateway}}{{end}}{{if .DnsServers}} --nameserver={{join .DnsServers ","}}{{end}} --hostname={{.Fqdn}} --activate
+selinux --disabled
+firewall --disabled
+firstboot --disable
+zerombr
+clearpart --all --initlabel
+autopart --type=lvm
+bootloader --append="crashkernel=auto"
+reboot --eject
+eula --agreed
+
+%packages
+@core
+%end
+
+%post
+echo "{{.Address}}  {{.Fqdn}}  {{.Hostname}}" >> /etc/hosts
+sed -i 's/^#\?PermitRootLogin .*/PermitRootLogin {{if .RootPasswordHash}}yes{{else}}prohibit-password{{end}}/' /etc/ssh/sshd_config
+sed -i 's/^#\?PubkeyAuthentication .*/PubkeyAuthentication yes/' /etc/ssh/sshd_config
+%end
+`
+
+// Kickstart image is generated by driver instead of prepared on web server
+func (s *Server) IsKickstartGenerated() bool {
+	return s.KsOutputDir != ""
+}
+
+func (s *Server) kickstartFormat() string {
+	if s.KsFormat == "" {
+		return ksFormatIso
+	}
+	return strings.ToLower(s.KsFormat)
+}
+
+func (s *Server) kickstartLabel() string {
+	if s.KsLabel != "" {
+		return s.KsLabel
+	}
+	return s.installMethod().label
+}
+
+// Kickstart image is named after IP address
+func (s *Server) kickstartImageName() string {
+	if s.IsKickstartGenerated() && s.IsHttpBoot() {
+		return s.Address + "-" + ksFileName
+	}
+	if s.IsKickstartGenerated() && s.kickstartFormat() == ksFormatFat {
+		return s.Address + ".img"
+	}
+	return s.Address + ".iso"
+}
+
+func (s *Server) updateKsUrl() {
+	s.KsUrl = fmt.Sprintf("%s/%s", s.KsBaseUrl, s.kickstartImageName())
+}
+
+func (s *Server) newKickstartImage() (kickstartImage, error) {
+	label := s.kickstartLabel()
+	m := s.installMethod()
+	if !m.fatName && s.kickstartFormat() == ksFormatFat {
+		return nil, fmt.Errorf("Image of %s should be %s format", s.installMethodName(), ksFormatIso)
+	}
+	if m.fixedLabel && !strings.EqualFold(label, m.label) {
+		return nil, fmt.Errorf("Volume label of %s image should be %s: %s", s.installMethodName(), m.label, label)
+	}
+	// Installer reads kickstart from URL on kernel command line of boot image
+	if s.IsHttpBoot() {
+		return &plainKickstart{}, nil
+	}
+	switch s.kickstartFormat() {
+	case ksFormatIso:
+		if len(label) > isoMaxLabel {
+			return nil, fmt.Errorf("Kickstart label %s is longer than %d characters", label, isoMaxLabel)
+		}
+		return newIsoImage(label), nil
+	case ksFormatFat:
+		if len(label) > fatMaxLabel {
+			return nil, fmt.Errorf("Kickstart label %s is longer than %d characters", label, fatMaxLabel)
+		}
+		return newFatImage(label), nil
+	default:
+		return nil, fmt.Errorf("Unknown kickstart format: %s. Specify %s or %s", s.KsFormat, ksFormatIso, ksFormatFat)
+	}
+}
+
+func (s *Server) kickstartTemplate() (*template.Template, error) {
+	text := s.installMethod().template
+	if s.KsTemplate != "" {
+		bytes, err := ioutil.ReadFile(s.KsTemplate)
+		if err != nil {
+			return nil, err
+		}
+		text = string(bytes)
+	}
+	tmpl := template.New(s.installMethod().fileName).Option("missingkey=error")
+	return tmpl.Funcs(installTemplateFuncs(tmpl)).Parse(text)
+}
+
+// Network settings come from allocated IP address or server options
+func (s *Server) kickstartParams() (*KickstartParams, error) {
+	params := &KickstartParams{
+		Hostname:     s.Hostname,
+		Fqdn:         s.Hostname,
+		Address:      s.Address,
+		Netmask:      s.Netmask,
+		Gateway:      s.Gateway,
+		DnsServers:   s.DnsServers,
+		Domain:       s.Domain,
+		SshPublicKey: s.SshPublicKey,
+	}
+	if a := s.Allocation; a != nil {
+		params.Netmask = a.Netmask
+		if params.Gateway == "" {
+			params.Gateway = a.Gateway
+		}
+		if len(params.DnsServers) == 0 {
+			params.DnsServers = a.DnsServers
+		}
+		if params.Domain == "" {
+			params.Domain = a.Domain
+		}
+	}
+	if params.Domain != "" {
+		params.Fqdn = fmt.Sprintf("%s.%s", s.Hostname, params.Domain)
+	}
+	if mask := net.ParseIP(params.Netmask).To4(); mask != nil {
+		params.PrefixLength, _ = net.IPv4Mask(mask[0], mask[1], mask[2], mask[3]).Size()
+	}
+	// Empty hash locks root password
+	if password := s.rootPassword(); password != "" {
+		hash, err := hashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		params.RootPasswordHash = hash
+	}
+	return params, nil
+}
+
+// Render kickstart file, or config of other install method, from template
+func (s *Server) RenderKickstart() ([]byte, error) {
+	tmpl, err := s.kickstartTemplate()
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	params, err := s.kickstartParams()
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if validate := s.installMethod().validate; validate != nil {
+		if err := validate(buf.Bytes()); err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Render kickstart and write labeled image into output directory
+func (s *Server) GenerateKickstart() error {
+	ks, err := s.RenderKickstart()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	image, err := s.newKickstartImage()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	for name, data := range s.installFiles(ks) {
+		if err := image.AddBytes(name, data); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	s.updateKsUrl()
+	path := filepath.Join(s.KsOutputDir, s.kickstartImageName())
+	tmpPath := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	_, err = image.WriteTo(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Infof("Kickstart image %s (label %s) is generated", path, s.kickstartLabel())
+	if err := s.GenerateHttpBootImage(); err != nil {
+		log.Error(Wrap(err))
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// Delete generated kickstart image
+func (s *Server) RemoveKickstart() error {
+	if !s.IsKickstartGenerated() || s.Address == "" {
+		return nil
+	}
+	path := filepath.Join(s.KsOutputDir, s.kickstartImageName())
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := s.RemoveHttpBootImage(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Check kickstart can be generated before server is created
+func (s *Server) validateKickstart() error {
+	info, err := os.Stat(s.KsOutputDir)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if !info.IsDir() {
+		err := fmt.Errorf("Kickstart output %s is not directory", s.KsOutputDir)
+		log.Error(Wrap(err))
+		return err
+	}
+	if _, err := s.newKickstartImage(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if s.Address != "" && s.Netmask == "" {
+		err := fmt.Errorf("Netmask is required to generate kickstart for server address %s", s.Address)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Template is rendered with sample values to find errors early
+	tmpl, err := s.kickstartTemplate()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	sample := &KickstartParams{
+		Hostname:         "host",
+		Fqdn:             "host.example.com",
+		Address:          "192.0.2.10",
+		Netmask:          "255.255.255.0",
+		Gateway:          "192.0.2.1",
+		DnsServers:       []string{"192.0.2.2"},
+		Domain:           "example.com",
+		PrefixLength:     24,
+		RootPasswordHash: "$6$salt$hash",
+		SshPublicKey:     "ssh-rsa AAAA",
+	}
+	if s.rootPassword() == "" {
+		sample.RootPasswordHash = ""
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, sample); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if validate := s.installMethod().validate; validate != nil {
+		if err := validate(buf.Bytes()); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package driver
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func createTestKickstartServer(t *testing.T, format string) (*Server, func()) {
+	dir, err := ioutil.TempDir("", "ov-ks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		Address:      "192.168.1.10",
+		Netmask:      "255.255.255.0",
+		Gateway:      "192.168.1.1",
+		DnsServers:   []string{"192.168.1.2", "192.168.1.3"},
+		Domain:       "example.com",
+		Hostname:     "node1",
+		KsBaseUrl:    "http://192.168.1.5/ks",
+		KsOutputDir:  dir,
+		KsFormat:     format,
+		RootPassword: "password",
+		SshPublicKey: "ssh-rsa AAAAB3NzaC1yc2E node1",
+	}
+	return s, func() { os.RemoveAll(dir) }
+}
+
+func TestKickstartRender(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	defer cleanup()
+
+	ks, err := s.RenderKickstart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"--ip=192.168.1.10 --netmask=255.255.255.0 --gateway=192.168.1.1 --nameserver=192.168.1.2,192.168.1.3 --hostname=node1.example.com",
+		`sshkey --username=root "ssh-rsa AAAAB3NzaC1yc2E node1"`,
+		"rootpw --iscrypted $6$",
+	} {
+		if !strings.Contains(string(ks), expected) {
+			t.Errorf("Kickstart does not contain %q:\n%s", expected, ks)
+		}
+	}
+	if strings.Contains(string(ks), "password") {
+		t.Error("Plain root password should not be written in kickstart")
+	}
+
+	// Allocated address overrides server options
+	s.SetAllocation(&IpAllocation{Address: "10.0.0.5", Netmask: "255.255.0.0", Gateway: "10.0.0.1"})
+	s.Gateway = ""
+	ks, err = s.RenderKickstart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(ks), "--ip=10.0.0.5 --netmask=255.255.0.0 --gateway=10.0.0.1") {
+		t.Errorf("Allocated address is not used:\n%s", ks)
+	}
+}
+
+func TestKickstartTemplate(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	defer cleanup()
+
+	s.KsTemplate = filepath.Join(s.KsOutputDir, "ks.tmpl")
+	if err := ioutil.WriteFile(s.KsTemplate, []byte("network --ip={{.Address}} --hostname={{.Fqdn}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ks, err := s.RenderKickstart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(ks) != "network --ip=192.168.1.10 --hostname=node1.example.com\n" {
+		t.Errorf("Unexpected kickstart: %s", ks)
+	}
+
+	// Unknown field is found before server is created
+	if err := ioutil.WriteFile(s.KsTemplate, []byte("{{.Unknown}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.validateKickstart(); err == nil {
+		t.Error("Template with unknown field should be error")
+	}
+}
+
+func TestKickstartImage(t *testing.T) {
+	cases := []struct {
+		format      string
+		name        string
+		labelOffset int
+		label       string
+	}{
+		{ksFormatIso, "192.168.1.10.iso", 32768 + 40, "ov-ks"},
+		{ksFormatFat, "192.168.1.10.img", 43, "ov-ks"},
+	}
+	for _, c := range cases {
+		s, cleanup := createTestKickstartServer(t, c.format)
+		if err := s.validateKickstart(); err != nil {
+			t.Error(err)
+		}
+		if err := s.GenerateKickstart(); err != nil {
+			t.Fatal(err)
+		}
+		if s.KsUrl != "http://192.168.1.5/ks/"+c.name {
+			t.Errorf("Unexpected kickstart URL: %s", s.KsUrl)
+		}
+		path := filepath.Join(s.KsOutputDir, c.name)
+		image, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(image[c.labelOffset:], []byte(c.label)) {
+			t.Errorf("Volume label of %s image is not %s: %q", c.format, c.label, image[c.labelOffset:c.labelOffset+11])
+		}
+		ks, _ := s.RenderKickstart()
+		// Root password hash has random salt
+		line := strings.SplitN(string(ks), "\n", 2)[0]
+		if !bytes.Contains(image, []byte(line)) {
+			t.Errorf("Kickstart is not written in %s image", c.format)
+		}
+
+		if err := s.RemoveKickstart(); err != nil {
+			t.Error(err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Kickstart image %s should be removed", path)
+		}
+		cleanup()
+	}
+}
+
+func TestKickstartInvalidLabel(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatFat)
+	defer cleanup()
+
+	s.KsLabel = "too-long-label"
+	if err := s.validateKickstart(); err == nil {
+		t.Error("FAT label longer than 11 characters should be error")
+	}
+	s.KsFormat = "vfat"
+	if err := s.validateKickstart(); err == nil {
+		t.Error("Unknown format should be error")
+	}
+}
+
+func TestKickstartKeyLogin(t *testing.T) {
+	s, cleanup := createTestKickstartServer(t, ksFormatIso)
+	defer cleanup()
+
+	// Root password is locked when public key is registered by kickstart
+	s.RootPassword = ""
+	if !s.isKeyLogin() || s.rootPassword() != "" {
+		t.Errorf("Key login should be used without root password: %v %q", s.isKeyLogin(), s.rootPassword())
+	}
+	for _, method := range []string{installMethodKickstart, installMethodAutoinstall, installMethodAutoyast} {
+		s.InstallMethod = method
+		config, err := s.RenderKickstart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(config), "ssh-rsa AAAAB3NzaC1yc2E node1") || strings.Contains(string(config), "$6$") {
+			t.Errorf("%s should have public key without root password:\n%s", method, config)
+		}
+	}
+	s.InstallMethod = ""
+	ks, err := s.RenderKickstart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(ks), "rootpw --lock") || !strings.Contains(string(ks), "PermitRootLogin prohibit-password") {
+		t.Errorf("Root password should be locked:\n%s", ks)
+	}
+
+	// Legacy password login uses default root password
+	s.PasswordLogin = true
+	if s.isKeyLogin() || s.rootPassword() != defaultRootPassword {
+		t.Errorf("Password login should be used: %v %q", s.isKeyLogin(), s.rootPassword())
+	}
+	s.PasswordLogin = false
+	s.KsOutputDir = ""
+	if s.isKeyLogin() {
+		t.Error("Password login should be used when kickstart is prepared on web server")
+	}
+
+	// Ignition does not support password login
+	s.KsOutputDir = "/tmp"
+	s.InstallMethod = installMethodIgnition
+	s.PasswordLogin = true
+	if err := s.validateInstallMethod(); err == nil {
+		t.Error("Password login with ignition should be error")
+	}
+}
@@ -1,119 +1,257 @@
 package driver
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
 	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
 	log "github.com/docker/machine/libmachine/log"
 	"github.com/docker/machine/libmachine/state"
 )
 
 type Oneview struct {
-	Endpoint                  string `yaml:"endpoint"`
-	ApiVersion                int    `yaml:"api-version"`
-	Username                  string `yaml:"user"`
-	Password                  string `yaml:"password"`
-	Domain                    string `yaml:"domain,omitempty"`
-	ServerProfileTemplateName string `yaml:"server-profile-template"`
-	ServerProfileName         string `yaml:"server-profile"`
-	ServerHardwareName        string `yaml:"server-hardware"`
+	Endpoint                  string              `yaml:"endpoint"`
+	ApiVersionSetting         string              `yaml:"api-version"` // Number or auto
+	ApiVersion                int                 `yaml:"-"`           // Negotiated API version
+	Username                  string              `yaml:"user"`
+	Password                  string              `yaml:"password"`
+	Domain                    string              `yaml:"domain,omitempty"`
+	ServerProfileTemplateName string              `yaml:"server-profile-template"`
+	ServerProfileName         string              `yaml:"server-profile"`
+	ServerHardwareName        string              `yaml:"server-hardware"`
+	ServerHardwarePool        *ServerHardwarePool `yaml:"server-hardware-pool,omitempty"`
+	IpPool                    *IpPool             `yaml:"ip-pool,omitempty"`
+	TaskTimeout               int                 `yaml:"task-timeout,omitempty"`
+	PowerTimeout              int                 `yaml:"power-timeout,omitempty"`
+	ResetType                 string              `yaml:"reset-type,omitempty"` // warm or cold
+	Tls                       *TlsConfig          `yaml:"tls,omitempty"`
+	ProfileOverrides          *ProfileOverrides   `yaml:"profile-overrides,omitempty"`
+	SessionCacheDir           string              `yaml:"-"`
 }
 
 // Precheck
 func (o *Oneview) Validate() error {
 	log.Debugf("OneView Structure: %#v", o)
-	_, err := o.NewClient()
+	if o.ServerHardwarePool.IsEnabled() && o.ServerHardwareName != "" {
+		err := fmt.Errorf("Server hardware name and server hardware pool can not be used at the same time")
+		log.Error(Wrap(err))
+		return err
+	}
+	if !o.ServerHardwarePool.IsEnabled() && o.ServerHardwareName == "" {
+		err := fmt.Errorf("Server hardware name or server hardware pool is required")
+		log.Error(Wrap(err))
+		return err
+	}
+	if _, err := resetControl(o.ResetType); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Negotiate again because setting may be changed
+	o.ApiVersion = 0
+	ovc, err := o.NewClient()
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+
+	if o.IpPool.IsEnabled() {
+		if err := o.ValidateIpPool(ovc); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	// Overrides are checked against template before server profile is created
+	if !o.ProfileOverrides.IsEmpty() {
+		template, err := ovc.GetProfileTemplateByName(o.ServerProfileTemplateName)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if template.Name == "" {
+			err := fmt.Errorf("Server profile template %s does not exist", o.ServerProfileTemplateName)
+			log.Error(Wrap(err))
+			return err
+		}
+		if err := o.ProfileOverrides.Validate(ovc, template); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
 	return nil
 }
 
 // Createt HPE OneView Client
+// Login session is shared between operations and driver processes.
 func (o *Oneview) NewClient() (*ov.OVClient, error) {
-	var ovClient *ov.OVClient
-
-	//If pass invalid API ver, this will be panic.
-	ovc := ovClient.NewOVClient(
-		o.Username,
-		o.Password,
-		o.Domain,
-		o.Endpoint,
-		false, //ssl verificcation
-		o.ApiVersion,
-		"*")
-
-	log.Debugf("Trying to connect HPE OneView endpoint at %v with API Ver %v", o.Endpoint, o.ApiVersion)
-	_, err := ovc.GetAPIVersion()
+	ovc, err := o.newOVClient()
 	if err != nil {
 		log.Error(Wrap(err))
 		return nil, err
 	}
+
+	// API version is negotiated once and saved in driver state
+	if o.ApiVersion == 0 {
+		if err := o.resolveApiVersion(ovc); err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+	} else if session := o.sessionCache().Load(o.Endpoint, o.Domain, o.Username); session == nil {
+		log.Debugf("Trying to connect HPE OneView endpoint at %v with API Ver %v", o.Endpoint, o.ApiVersion)
+		_, err := ovc.GetAPIVersion()
+		if err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+	}
+	if err := o.refreshSession(ovc); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
 	log.Debugf("Connected %v with API Ver %v", o.Endpoint, o.ApiVersion)
 
 	return ovc, nil
 }
 
-func (o *Oneview) GetServerStatus() (string, error) {
-	hardwareName := o.ServerHardwareName
-	log.Infof("Get hardware status for %s", hardwareName)
+// All requests including login are sent with HTTP client which verifies certificate of HPE OneView
+func (o *Oneview) newOVClient() (*ov.OVClient, error) {
+	u, err := url.Parse(o.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		log.Warnf("%s is not https. Connection is not encrypted.", o.Endpoint)
+	}
+	httpClient, err := o.tlsConfig().HttpClient(u.Hostname(), o.tlsConfig().Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	return &ov.OVClient{
+		Client: rest.Client{
+			User:       o.Username,
+			Password:   o.Password,
+			Domain:     o.Domain,
+			Endpoint:   o.Endpoint,
+			SSLVerify:  !o.tlsConfig().Insecure,
+			APIVersion: o.ApiVersion,
+			APIKey:     "none",
+			IfMatch:    "*",
+			HTTPClient: httpClient,
+		},
+	}, nil
+}
+
+func (o *Oneview) tlsConfig() *TlsConfig {
+	if o.Tls == nil {
+		o.Tls = &TlsConfig{}
+	}
+	return o.Tls
+}
+
+func (o *Oneview) newTaskWatcher(ovc *ov.OVClient) *TaskWatcher {
+	w := NewTaskWatcher(ovc, o.TaskTimeout)
+	w.Refresh = o.refreshSession
+	return w
+}
+
+// Server state from power state, hardware status and server profile state
+func (o *Oneview) GetState() (state.State, error) {
 	ovc, err := o.NewClient()
 	if err != nil {
 		log.Error(Wrap(err))
-		return "", err
+		return state.Error, err
 	}
 
 	//Get Server hardware infomation
+	hardwareName := o.ServerHardwareName
 	hardware, err := ovc.GetServerHardwareByName(hardwareName)
 	if err != nil {
 		log.Error(Wrap(err))
-		return "", err
+		return state.Error, err
+	}
+	if hardware.Name == "" {
+		err := fmt.Errorf("Server hardware %s does not exist", hardwareName)
+		log.Error(Wrap(err))
+		return state.Error, err
+	}
+	log.Debugf("Server hardware %s: power=%s status=%s", hardwareName, hardware.PowerState, hardware.Status)
+	if hardware.Status == ovStatusCritical {
+		err := fmt.Errorf("Server hardware %s is %s. Check alerts on HPE OneView", hardwareName, hardware.Status)
+		log.Error(Wrap(err))
+		return state.Error, err
+	}
+
+	//Get Server profile infomation
+	profile, err := ovc.GetProfileByName(o.ServerProfileName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return state.Error, err
 	}
-	return hardware.Status, nil
+	if profile.Name != "" {
+		log.Debugf("Server profile %s: state=%s status=%s", profile.Name, profile.State, profile.Status)
+		if strings.HasSuffix(profile.State, "Failed") || profile.Status == ovStatusCritical {
+			err := fmt.Errorf("Server profile %s is %s (status: %s). Check server profile on HPE OneView", profile.Name, profile.State, profile.Status)
+			log.Error(Wrap(err))
+			return state.Error, err
+		}
+	}
+
+	return powerStateOf(hardware.PowerState)
 }
 
+// Power on with momentary press
 func (o *Oneview) PowerOn() error {
-	ovc, err := o.NewClient()
-	if err != nil {
+	if err := o.setPowerState(ov.P_ON, ov.P_MOMPRESS); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	return nil
+}
 
-	//Get Server hardware infomation
-	hardwareName := o.ServerHardwareName
-	hardware, err := ovc.GetServerHardwareByName(hardwareName)
-	if err != nil {
+// Power off forcibly with press and hold
+func (o *Oneview) PowerOff() error {
+	if err := o.setPowerState(ov.P_OFF, ov.P_PRESSANDHOLD); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	return nil
+}
 
-	log.Infof("Power on %s", hardwareName)
-	var powerTask *ov.PowerTask
-	powerTask = powerTask.NewPowerTask(hardware)
-	powerTask.GetCurrentPowerState()
-	powerTask.PowerExecutor(1)
+// Power off gracefully with momentary press
+func (o *Oneview) ShutDown() error {
+	if err := o.setPowerState(ov.P_OFF, ov.P_MOMPRESS); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
 	return nil
 }
 
-func (o *Oneview) PowerOff() error {
-	ovc, err := o.NewClient()
+// Reset server with warm or cold reset. Powered off server is powered on.
+func (o *Oneview) Reset() error {
+	control, err := resetControl(o.ResetType)
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
 
-	//Get Server hardware infomation
-	hardwareName := o.ServerHardwareName
-	hardware, err := ovc.GetServerHardwareByName(hardwareName)
+	powerState, err := o.GetPowerState()
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	if powerState == state.Stopped {
+		log.Infof("%s is powered off. Power on instead of reset", o.ServerHardwareName)
+		return o.PowerOn()
+	}
 
-	log.Infof("Power off %s", hardwareName)
-	var powerTask *ov.PowerTask
-	powerTask = powerTask.NewPowerTask(hardware)
-	powerTask.GetCurrentPowerState()
-	powerTask.PowerExecutor(2)
+	if err := o.setPowerState(ov.P_ON, control); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
 	return nil
 }
 
@@ -133,23 +271,8 @@ func (o *Oneview) GetPowerState() (state.State, error) {
 	}
 
 	log.Infof("Get server power state of %s", hardwareName)
-	var powerTask *ov.PowerTask
-	powerTask = powerTask.NewPowerTask(hardware)
-	powerTask.GetCurrentPowerState()
-	powerState := powerTask.State
-	log.Debugf("Power state is %v of %s", powerState, hardwareName)
-
-	switch powerState {
-	case ov.P_ON:
-		return state.Running, nil
-	case ov.P_OFF:
-		return state.Stopped, nil
-	case ov.P_UKNOWN:
-		return state.Error, nil
-	default:
-		return state.Error, nil
-	}
-	return state.Error, nil
+	log.Debugf("Power state is %v of %s", hardware.PowerState, hardwareName)
+	return powerStateOf(hardware.PowerState)
 }
 
 func (o *Oneview) CreateServerProfile() error {
@@ -166,6 +289,11 @@ func (o *Oneview) CreateServerProfile() error {
 		log.Error(Wrap(err))
 		return err
 	}
+	if serverProfileTemplate.Name == "" {
+		err := fmt.Errorf("Server profile template %s does not exist", serverProfileTemplateName)
+		log.Error(Wrap(err))
+		return err
+	}
 
 	//Get Server hardware infomation
 	hardwareName := o.ServerHardwareName
@@ -175,16 +303,139 @@ func (o *Oneview) CreateServerProfile() error {
 		return err
 	}
 
+	// Process may be killed while profile is created in previous run
+	adopted, err := o.adoptServerProfile(ovc, hardware)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if adopted {
+		return nil
+	}
+
+	available, err := ovc.GetAvailableServers(hardware.URI.String())
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if !available {
+		err := fmt.Errorf("Server hardware %s is not available for new server profile", hardwareName)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Server should be powered off to apply server profile
+	if !ov.P_OFF.Equal(hardware.PowerState) {
+		if err := o.PowerOff(); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
 	serverProfileName := o.ServerProfileName
 	log.Infof("Create server profile %s from %s", serverProfileName, serverProfileTemplateName)
+	serverProfile, err := ovc.GetProfileByURI(serverProfileTemplate.URI)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	serverProfile.Type = serverProfileType(ovc.APIVersion)
+	serverProfile.ServerProfileTemplateURI = serverProfileTemplate.URI
+	serverProfile.ConnectionSettings = ov.ConnectionSettings{
+		Connections: serverProfileTemplate.ConnectionSettings.Connections,
+	}
+	serverProfile.ServerHardwareURI = hardware.URI
+	serverProfile.Description += " " + serverProfileName
+	serverProfile.Name = serverProfileName
+	if err := o.ProfileOverrides.Apply(ovc, &serverProfile); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Debugf("Server profile: %#v", serverProfile)
 
-	if err := ovc.CreateProfileFromTemplate(serverProfileName, serverProfileTemplate, hardware); err != nil {
+	ovc.RefreshLogin()
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(rest.POST, "/rest/server-profiles", serverProfile)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	var task ov.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	if err := o.newTaskWatcher(ovc).Wait(task.URI.String()); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
 	return nil
 }
 
+// Use server profile which already exists on target server hardware.
+// Return false when server profile does not exist.
+func (o *Oneview) adoptServerProfile(ovc *ov.OVClient, hardware ov.ServerHardware) (bool, error) {
+	serverProfileName := o.ServerProfileName
+	profile, err := ovc.GetProfileByName(serverProfileName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return false, err
+	}
+	if profile.Name == "" {
+		return false, nil
+	}
+	if profile.ServerHardwareURI != hardware.URI {
+		err := fmt.Errorf("Server profile %s already exists and is not assigned to %s", serverProfileName, hardware.Name)
+		log.Error(Wrap(err))
+		return false, err
+	}
+
+	log.Infof("Server profile %s already exists on %s. Use it", serverProfileName, hardware.Name)
+	if err := o.waitRunningTasks(ovc, profile.URI.String()); err != nil {
+		log.Error(Wrap(err))
+		return false, err
+	}
+	profile, err = ovc.GetProfileByName(serverProfileName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return false, err
+	}
+	if strings.HasSuffix(profile.State, "Failed") || profile.Status == ovStatusCritical {
+		err := fmt.Errorf("Server profile %s is %s (status: %s). Check server profile on HPE OneView", profile.Name, profile.State, profile.Status)
+		log.Error(Wrap(err))
+		return false, err
+	}
+	return true, nil
+}
+
+// Wait tasks which are still running on resource
+func (o *Oneview) waitRunningTasks(ovc *ov.OVClient, resourceUri string) error {
+	ovc.SetQueryString(map[string]interface{}{
+		"filter": []string{
+			fmt.Sprintf("associatedResource.resourceUri='%s'", resourceUri),
+			"taskState='Running'",
+		},
+	})
+	var tasks struct {
+		Members []ov.Task `json:"members"`
+	}
+	err := ovRestCall(ovc, rest.GET, "/rest/tasks", nil, &tasks)
+	ovc.SetQueryString(nil)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	for _, task := range tasks.Members {
+		log.Infof("Wait for running task \"%s\" of %s", task.Name, resourceUri)
+		if err := o.newTaskWatcher(ovc).Wait(task.URI.String()); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *Oneview) DeleteServerProfile() error {
 	ovc, err := o.NewClient()
 	if err != nil {
@@ -193,12 +444,127 @@ func (o *Oneview) DeleteServerProfile() error {
 	}
 
 	serverProfileName := o.ServerProfileName
+	profile, err := ovc.GetProfileByName(serverProfileName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if profile.Name == "" {
+		log.Infof("Server profile %s does not exist. Skip to delete", serverProfileName)
+		return nil
+	}
+
+	// Server should be powered off to remove server profile
+	if profile.ServerHardwareURI != "" {
+		hardware, err := ovc.GetServerHardwareByUri(profile.ServerHardwareURI)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if !ov.P_OFF.Equal(hardware.PowerState) {
+			if err := o.PowerOff(); err != nil {
+				log.Error(Wrap(err))
+				return err
+			}
+		}
+	}
+
 	log.Infof("Delete server profile %s", serverProfileName)
-	err = ovc.DeleteProfile(serverProfileName)
+	ovc.RefreshLogin()
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(rest.DELETE, profile.URI.String(), nil)
 	if err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
+	var task ov.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
 	//Wait delete completion
-	return err
+	if err := o.newTaskWatcher(ovc).Wait(task.URI.String()); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Server profile resource type for API version
+func serverProfileType(apiVersion int) string {
+	switch {
+	case apiVersion >= 1600:
+		return "ServerProfileV12"
+	case apiVersion >= 1200:
+		return "ServerProfileV11"
+	case apiVersion >= 1000:
+		return "ServerProfileV10"
+	case apiVersion >= 800:
+		return "ServerProfileV9"
+	case apiVersion >= 600:
+		return "ServerProfileV8"
+	case apiVersion >= 500:
+		return "ServerProfileV7"
+	case apiVersion >= 300:
+		return "ServerProfileV6"
+	default:
+		return "ServerProfileV5"
+	}
+}
+
+// Check server profile exists and is assigned to target server hardware
+func (o *Oneview) CheckServerProfile() error {
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	serverProfileName := o.ServerProfileName
+	profile, err := ovc.GetProfileByName(serverProfileName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if profile.Name == "" {
+		err := fmt.Errorf("Server profile %s does not exist", serverProfileName)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	hardwareName := o.ServerHardwareName
+	hardware, err := ovc.GetServerHardwareByName(hardwareName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if profile.ServerHardwareURI != hardware.URI {
+		err := fmt.Errorf("Server profile %s is not assigned to %s", serverProfileName, hardwareName)
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Check target server hardware exists in OneView
+func (o *Oneview) CheckServerHardware() error {
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	hardwareName := o.ServerHardwareName
+	hardware, err := ovc.GetServerHardwareByName(hardwareName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if hardwareName == "" || hardware.Name != hardwareName {
+		err := fmt.Errorf("Server hardware %s does not exist", hardwareName)
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
 }
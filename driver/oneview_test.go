@@ -99,3 +99,38 @@ func TestOneviewServerProfileHandling(t *testing.T) {
 	}
 
 }
+
+func TestOneviewProfileAdopt(t *testing.T) {
+	s := createTestPowerServer("Off")
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+	o.ServerProfileName = "ov-docker-machine-node1"
+	o.ServerProfileTemplateName = "Rancher-template"
+	s.template = `{"name":"Rancher-template","uri":"/rest/server-profile-templates/1"}`
+
+	// Previous run was killed while task to create server profile is running
+	s.profile = `{"name":"ov-docker-machine-node1","uri":"/rest/server-profiles/1","serverHardwareUri":"/rest/server-hardware/bay1","state":"Normal","status":"OK"}`
+	s.tasks = `{"name":"Create","uri":"/rest/tasks/profile","taskState":"Running"}`
+	if err := o.CreateServerProfile(); err != nil {
+		t.Fatal(err)
+	}
+	if s.creations != 0 {
+		t.Errorf("Server profile should not be created again: %d", s.creations)
+	}
+
+	// Profile creation failed in previous run
+	s.tasks = ""
+	s.profile = `{"name":"ov-docker-machine-node1","uri":"/rest/server-profiles/1","serverHardwareUri":"/rest/server-hardware/bay1","state":"CreateFailed","status":"Critical"}`
+	if err := o.CreateServerProfile(); err == nil {
+		t.Error("Failed server profile should not be used")
+	}
+
+	// Profile of same name on other server hardware
+	s.profile = `{"name":"ov-docker-machine-node1","uri":"/rest/server-profiles/1","serverHardwareUri":"/rest/server-hardware/bay2","state":"Normal","status":"OK"}`
+	if err := o.CreateServerProfile(); err == nil {
+		t.Error("Server profile on other server hardware should not be used")
+	}
+	if s.creations != 0 {
+		t.Errorf("Server profile should not be created: %d", s.creations)
+	}
+}
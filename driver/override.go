@@ -0,0 +1,359 @@
+package driver
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+var (
+	profileBootModes   = []string{"UEFI", "UEFIOptimized", "BIOS"}
+	profileBootDevices = []string{"CD", "Floppy", "USB", "HardDisk", "PXE", "FibreChannelHba"}
+)
+
+// Per-machine changes applied to server profile created from template
+type ProfileOverrides struct {
+	Connections   []ConnectionOverride   `yaml:"connections,omitempty"`
+	LogicalDrives []LogicalDriveOverride `yaml:"logical-drives,omitempty"`
+	Bios          map[string]string      `yaml:"bios,omitempty"`       // BIOS setting ID and value
+	BootMode      string                 `yaml:"boot-mode,omitempty"`  // UEFI, UEFIOptimized or BIOS
+	BootOrder     []string               `yaml:"boot-order,omitempty"` // CD, Floppy, USB, HardDisk, PXE or FibreChannelHba
+	invalid       []string               // Invalid keys and values found when reading config
+}
+
+// Connection in template is selected by ID
+type ConnectionOverride struct {
+	Id            int    `yaml:"id"`
+	Network       string `yaml:"network,omitempty"`        // Ethernet network, FC network or network set name
+	RequestedMbps string `yaml:"requested-mbps,omitempty"` // Requested bandwidth
+	BootPriority  string `yaml:"boot-priority,omitempty"`  // Primary, Secondary or NotBootable
+	invalid       []string
+}
+
+// Logical drive in template is selected by controller device slot and drive name
+type LogicalDriveOverride struct {
+	DeviceSlot        string `yaml:"device-slot"` // Embedded, Mezz 1 ...
+	Name              string `yaml:"name"`
+	RaidLevel         string `yaml:"raid-level,omitempty"`
+	NumPhysicalDrives int    `yaml:"num-physical-drives,omitempty"`
+	Bootable          *bool  `yaml:"bootable,omitempty"`
+	invalid           []string
+}
+
+func (p *ProfileOverrides) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain ProfileOverrides
+	if err := unmarshal((*plain)(p)); err != nil {
+		return err
+	}
+	p.invalid = unknownYamlKeys(unmarshal, p, "profile-overrides")
+	return nil
+}
+
+func (c *ConnectionOverride) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain ConnectionOverride
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	c.invalid = unknownYamlKeys(unmarshal, c, "profile-overrides.connections")
+	return nil
+}
+
+func (l *LogicalDriveOverride) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain LogicalDriveOverride
+	if err := unmarshal((*plain)(l)); err != nil {
+		return err
+	}
+	l.invalid = unknownYamlKeys(unmarshal, l, "profile-overrides.logical-drives")
+	return nil
+}
+
+// Return yaml keys which are not defined in struct
+func unknownYamlKeys(unmarshal func(interface{}) error, v interface{}, section string) []string {
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return []string{fmt.Sprintf("%s: %v", section, err)}
+	}
+	known := map[string]bool{}
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+	var unknown []string
+	for key := range raw {
+		if !known[key] {
+			unknown = append(unknown, fmt.Sprintf("unknown key %s.%s", section, key))
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Create overrides from command options
+func NewProfileOverridesFromFlags(connections, logicalDrives, bios []string, bootMode string, bootOrder []string) *ProfileOverrides {
+	p := &ProfileOverrides{
+		BootMode:  bootMode,
+		BootOrder: bootOrder,
+	}
+	// ID=NETWORK
+	for _, opt := range connections {
+		kv := strings.SplitN(opt, "=", 2)
+		id, err := strconv.Atoi(strings.TrimSpace(kv[0]))
+		if len(kv) != 2 || err != nil || kv[1] == "" {
+			p.invalid = append(p.invalid, fmt.Sprintf("connection %q should be ID=NETWORK", opt))
+			continue
+		}
+		p.Connections = append(p.Connections, ConnectionOverride{Id: id, Network: kv[1]})
+	}
+	// DEVICE_SLOT:DRIVE_NAME=RAID_LEVEL
+	for _, opt := range logicalDrives {
+		kv := strings.SplitN(opt, "=", 2)
+		drive := strings.SplitN(kv[0], ":", 2)
+		if len(kv) != 2 || len(drive) != 2 || kv[1] == "" {
+			p.invalid = append(p.invalid, fmt.Sprintf("logical drive %q should be DEVICE_SLOT:DRIVE_NAME=RAID_LEVEL", opt))
+			continue
+		}
+		p.LogicalDrives = append(p.LogicalDrives, LogicalDriveOverride{DeviceSlot: drive[0], Name: drive[1], RaidLevel: kv[1]})
+	}
+	// ID=VALUE
+	for _, opt := range bios {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			p.invalid = append(p.invalid, fmt.Sprintf("bios %q should be ID=VALUE", opt))
+			continue
+		}
+		if p.Bios == nil {
+			p.Bios = map[string]string{}
+		}
+		p.Bios[kv[0]] = kv[1]
+	}
+	return p
+}
+
+func (p *ProfileOverrides) IsEmpty() bool {
+	return p == nil || (len(p.Connections) == 0 && len(p.LogicalDrives) == 0 && len(p.Bios) == 0 &&
+		p.BootMode == "" && len(p.BootOrder) == 0 && len(p.invalid) == 0)
+}
+
+// Check overrides against server profile template
+func (p *ProfileOverrides) Validate(ovc *ov.OVClient, template ov.ServerProfile) error {
+	if p.IsEmpty() {
+		return nil
+	}
+	log.Debugf("Profile overrides: %#v", p)
+
+	problems := append([]string{}, p.invalid...)
+	if p.BootMode != "" && !containsString(profileBootModes, p.BootMode) {
+		problems = append(problems, fmt.Sprintf("boot mode %s is not one of %v", p.BootMode, profileBootModes))
+	}
+	for _, device := range p.BootOrder {
+		if !containsString(profileBootDevices, device) {
+			problems = append(problems, fmt.Sprintf("boot device %s is not one of %v", device, profileBootDevices))
+		}
+	}
+
+	for _, c := range p.Connections {
+		problems = append(problems, c.invalid...)
+		if findConnection(template.ConnectionSettings.Connections, c.Id) == nil {
+			problems = append(problems, fmt.Sprintf("connection %d does not exist in template", c.Id))
+		}
+		if c.Network != "" {
+			if _, err := findNetworkUri(ovc, c.Network); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	for _, l := range p.LogicalDrives {
+		problems = append(problems, l.invalid...)
+		if findLogicalDrive(template.LocalStorage.Controllers, l.DeviceSlot, l.Name) == nil {
+			problems = append(problems, fmt.Sprintf("logical drive %s on %s does not exist in template", l.Name, l.DeviceSlot))
+		}
+	}
+
+	if len(p.Bios) > 0 {
+		hardwareType, err := ovc.GetServerHardwareTypeByUri(template.ServerHardwareTypeURI)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		settings := map[string]ov.BiosSetting{}
+		for _, setting := range hardwareType.BiosSettings {
+			settings[setting.ID] = setting
+		}
+		for id, value := range p.Bios {
+			setting, ok := settings[id]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("BIOS setting %s does not exist in %s", id, hardwareType.Name))
+				continue
+			}
+			if len(setting.Options) > 0 && !hasBiosOption(setting.Options, value) {
+				problems = append(problems, fmt.Sprintf("%s is not valid value of BIOS setting %s", value, id))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		err := fmt.Errorf("Invalid server profile overrides: %s", strings.Join(problems, ", "))
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Patch server profile generated from template
+func (p *ProfileOverrides) Apply(ovc *ov.OVClient, profile *ov.ServerProfile) error {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	for _, c := range p.Connections {
+		connection := findConnection(profile.ConnectionSettings.Connections, c.Id)
+		if connection == nil {
+			err := fmt.Errorf("Connection %d does not exist in server profile", c.Id)
+			log.Error(Wrap(err))
+			return err
+		}
+		if c.Network != "" {
+			uri, err := findNetworkUri(ovc, c.Network)
+			if err != nil {
+				log.Error(Wrap(err))
+				return err
+			}
+			log.Infof("Override network of connection %d with %s", c.Id, c.Network)
+			connection.NetworkURI = uri
+			connection.NetworkName = ""
+		}
+		if c.RequestedMbps != "" {
+			connection.RequestedMbps = c.RequestedMbps
+		}
+		if c.BootPriority != "" {
+			if connection.Boot == nil {
+				connection.Boot = &ov.BootOption{}
+			}
+			connection.Boot.Priority = c.BootPriority
+		}
+	}
+
+	for _, l := range p.LogicalDrives {
+		drive := findLogicalDrive(profile.LocalStorage.Controllers, l.DeviceSlot, l.Name)
+		if drive == nil {
+			err := fmt.Errorf("Logical drive %s on %s does not exist in server profile", l.Name, l.DeviceSlot)
+			log.Error(Wrap(err))
+			return err
+		}
+		log.Infof("Override logical drive %s on %s", l.Name, l.DeviceSlot)
+		if l.RaidLevel != "" {
+			drive.RaidLevel = l.RaidLevel
+		}
+		if l.NumPhysicalDrives > 0 {
+			drive.NumPhysicalDrives = l.NumPhysicalDrives
+		}
+		if l.Bootable != nil {
+			drive.Bootable = l.Bootable
+		}
+	}
+
+	if len(p.Bios) > 0 {
+		manage := true
+		if profile.Bios == nil {
+			profile.Bios = &ov.BiosOption{}
+		}
+		profile.Bios.ManageBios = &manage
+		ids := make([]string, 0, len(p.Bios))
+		for id := range p.Bios {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			log.Infof("Override BIOS setting %s with %s", id, p.Bios[id])
+			profile.Bios.OverriddenSettings = setBiosSetting(profile.Bios.OverriddenSettings, id, p.Bios[id])
+		}
+	}
+
+	if p.BootMode != "" {
+		log.Infof("Override boot mode with %s", p.BootMode)
+		manage := true
+		profile.BootMode.ManageMode = &manage
+		profile.BootMode.Mode = p.BootMode
+	}
+
+	if len(p.BootOrder) > 0 {
+		log.Infof("Override boot order with %v", p.BootOrder)
+		profile.Boot.ManageBoot = true
+		profile.Boot.Order = p.BootOrder
+	}
+	return nil
+}
+
+func findConnection(connections []ov.Connection, id int) *ov.Connection {
+	for i := range connections {
+		if connections[i].ID == id {
+			return &connections[i]
+		}
+	}
+	return nil
+}
+
+func findLogicalDrive(controllers []ov.LocalStorageEmbeddedController, deviceSlot, name string) *ov.LogicalDriveV3 {
+	for i := range controllers {
+		if controllers[i].DeviceSlot != deviceSlot {
+			continue
+		}
+		for j := range controllers[i].LogicalDrives {
+			if controllers[i].LogicalDrives[j].Name == name {
+				return &controllers[i].LogicalDrives[j]
+			}
+		}
+	}
+	return nil
+}
+
+// Search ethernet network, network set, FC network and FCoE network by name
+func findNetworkUri(ovc *ov.OVClient, name string) (utils.Nstring, error) {
+	ethernet, err := ovc.GetEthernetNetworkByName(name)
+	if err == nil && ethernet.Name == name {
+		return ethernet.URI, nil
+	}
+	networkSet, err := ovc.GetNetworkSetByName(name)
+	if err == nil && networkSet.Name == name {
+		return networkSet.URI, nil
+	}
+	fc, err := ovc.GetFCNetworkByName(name)
+	if err == nil && fc.Name == name {
+		return fc.URI, nil
+	}
+	fcoe, err := ovc.GetFCoENetworkByName(name)
+	if err == nil && fcoe.Name == name {
+		return fcoe.URI, nil
+	}
+	return "", fmt.Errorf("network %s does not exist", name)
+}
+
+func hasBiosOption(options []ov.Option, value string) bool {
+	for _, option := range options {
+		if option.ID == value {
+			return true
+		}
+	}
+	return false
+}
+
+func setBiosSetting(settings []ov.BiosSettings, id, value string) []ov.BiosSettings {
+	for i := range settings {
+		if settings[i].ID == id {
+			settings[i].Value = value
+			return settings
+		}
+	}
+	return append(settings, ov.BiosSettings{ID: id, Value: value})
+}
@@ -0,0 +1,132 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"gopkg.in/yaml.v2"
+)
+
+func createTestTemplate() ov.ServerProfile {
+	return ov.ServerProfile{
+		ConnectionSettings: ov.ConnectionSettings{
+			Connections: []ov.Connection{
+				{ID: 1, RequestedMbps: "2500"},
+				{ID: 2, RequestedMbps: "2500"},
+			},
+		},
+		LocalStorage: ov.LocalStorageOptions{
+			Controllers: []ov.LocalStorageEmbeddedController{
+				{DeviceSlot: "Embedded", LogicalDrives: []ov.LogicalDriveV3{{Name: "boot", RaidLevel: "RAID1"}}},
+			},
+		},
+	}
+}
+
+func TestProfileOverridesUnknownKeys(t *testing.T) {
+	data := `
+connections:
+  - id: 1
+    requested-mbps: 5000
+    vlan: 10
+logical-drives:
+  - device-slot: Embedded
+    name: boot
+    raid-level: RAID0
+boot-mode: UEFI
+boot-sequence: [CD]
+`
+	var p ProfileOverrides
+	if err := yaml.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	err := p.Validate(nil, createTestTemplate())
+	if err == nil {
+		t.Fatal("Unknown keys should be error")
+	}
+	for _, key := range []string{"profile-overrides.boot-sequence", "profile-overrides.connections.vlan"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("%s should be reported: %v", key, err)
+		}
+	}
+}
+
+func TestProfileOverridesValidate(t *testing.T) {
+	cases := []struct {
+		overrides ProfileOverrides
+		isError   bool
+	}{
+		{overrides: ProfileOverrides{BootMode: "UEFIOptimized", BootOrder: []string{"CD", "HardDisk"}}},
+		{overrides: ProfileOverrides{BootMode: "Legacy"}, isError: true},
+		{overrides: ProfileOverrides{BootOrder: []string{"Network"}}, isError: true},
+		{overrides: ProfileOverrides{Connections: []ConnectionOverride{{Id: 3, RequestedMbps: "1000"}}}, isError: true},
+		{overrides: ProfileOverrides{LogicalDrives: []LogicalDriveOverride{{DeviceSlot: "Embedded", Name: "boot", RaidLevel: "RAID0"}}}},
+		{overrides: ProfileOverrides{LogicalDrives: []LogicalDriveOverride{{DeviceSlot: "Mezz 1", Name: "boot"}}}, isError: true},
+	}
+	for i, c := range cases {
+		if err := c.overrides.Validate(nil, createTestTemplate()); (err != nil) != c.isError {
+			t.Errorf("Case %d: unexpected result %v", i, err)
+		}
+	}
+}
+
+func TestProfileOverridesFromFlags(t *testing.T) {
+	p := NewProfileOverridesFromFlags(
+		[]string{"1=prod", "x=dev"},
+		[]string{"Embedded:boot=RAID0", "boot"},
+		[]string{"WorkloadProfile=Virtualization-MaxPerformance"},
+		"UEFI",
+		[]string{"CD", "HardDisk"},
+	)
+	if len(p.Connections) != 1 || p.Connections[0].Id != 1 || p.Connections[0].Network != "prod" {
+		t.Errorf("Unexpected connections: %#v", p.Connections)
+	}
+	if len(p.LogicalDrives) != 1 || p.LogicalDrives[0].DeviceSlot != "Embedded" || p.LogicalDrives[0].RaidLevel != "RAID0" {
+		t.Errorf("Unexpected logical drives: %#v", p.LogicalDrives)
+	}
+	if p.Bios["WorkloadProfile"] != "Virtualization-MaxPerformance" {
+		t.Errorf("Unexpected BIOS settings: %#v", p.Bios)
+	}
+	if len(p.invalid) != 2 {
+		t.Errorf("Malformed options should be invalid: %v", p.invalid)
+	}
+
+	if !NewProfileOverridesFromFlags(nil, nil, nil, "", nil).IsEmpty() {
+		t.Error("Overrides without options should be empty")
+	}
+}
+
+func TestProfileOverridesApply(t *testing.T) {
+	bootable := true
+	p := &ProfileOverrides{
+		Connections:   []ConnectionOverride{{Id: 2, RequestedMbps: "10000", BootPriority: "Primary"}},
+		LogicalDrives: []LogicalDriveOverride{{DeviceSlot: "Embedded", Name: "boot", RaidLevel: "RAID0", Bootable: &bootable}},
+		Bios:          map[string]string{"WorkloadProfile": "Virtualization-MaxPerformance"},
+		BootMode:      "UEFI",
+		BootOrder:     []string{"CD", "HardDisk"},
+	}
+	profile := createTestTemplate()
+	profile.Bios = &ov.BiosOption{OverriddenSettings: []ov.BiosSettings{{ID: "WorkloadProfile", Value: "GeneralPowerEfficientCompute"}}}
+	if err := p.Apply(nil, &profile); err != nil {
+		t.Fatal(err)
+	}
+
+	connection := profile.ConnectionSettings.Connections[1]
+	if connection.RequestedMbps != "10000" || connection.Boot == nil || connection.Boot.Priority != "Primary" {
+		t.Errorf("Connection is not overridden: %#v", connection)
+	}
+	drive := profile.LocalStorage.Controllers[0].LogicalDrives[0]
+	if drive.RaidLevel != "RAID0" || drive.Bootable == nil || !*drive.Bootable {
+		t.Errorf("Logical drive is not overridden: %#v", drive)
+	}
+	if !*profile.Bios.ManageBios || len(profile.Bios.OverriddenSettings) != 1 || profile.Bios.OverriddenSettings[0].Value != "Virtualization-MaxPerformance" {
+		t.Errorf("BIOS is not overridden: %#v", profile.Bios)
+	}
+	if !*profile.BootMode.ManageMode || profile.BootMode.Mode != "UEFI" {
+		t.Errorf("Boot mode is not overridden: %#v", profile.BootMode)
+	}
+	if !profile.Boot.ManageBoot || len(profile.Boot.Order) != 2 {
+		t.Errorf("Boot order is not overridden: %#v", profile.Boot)
+	}
+}
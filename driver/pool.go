@@ -0,0 +1,251 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+// Pick target server hardware from OneView instead of fixed server hardware name
+type ServerHardwarePool struct {
+	Enabled   bool     `yaml:"enabled"`
+	Labels    []string `yaml:"labels,omitempty"`
+	Enclosure string   `yaml:"enclosure,omitempty"`
+	Scope     string   `yaml:"scope,omitempty"`
+}
+
+type ovResourceLabels struct {
+	ResourceUri string    `json:"resourceUri,omitempty"`
+	Labels      []ovLabel `json:"labels"`
+	Uri         string    `json:"uri,omitempty"`
+	ETag        string    `json:"eTag,omitempty"`
+}
+
+type ovLabel struct {
+	Name string `json:"name"`
+	Uri  string `json:"uri,omitempty"`
+}
+
+type ovResourceScopes struct {
+	ScopeUris []string `json:"scopeUris"`
+}
+
+func (p *ServerHardwarePool) IsEnabled() bool {
+	return p != nil && p.Enabled
+}
+
+// Select free server hardware which fits server profile template.
+// Each candidate is passed to reserve and first reserved hardware name is set to ServerHardwareName.
+func (o *Oneview) SelectServerHardware(reserve func(hardwareName string) error) error {
+	pool := o.ServerHardwarePool
+	log.Infof("Select server hardware from pool: %#v", pool)
+	candidates, err := o.ListFreeServerHardware()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	for _, hardware := range candidates {
+		if err := reserve(hardware.Name); err != nil {
+			log.Infof("Server hardware %s can not be reserved: %v", hardware.Name, err)
+			continue
+		}
+		o.ServerHardwareName = hardware.Name
+		log.Infof("Server hardware %s is selected", o.ServerHardwareName)
+		return nil
+	}
+
+	err = fmt.Errorf("No free hardware in pool for %s", o.ServerProfileTemplateName)
+	log.Error(Wrap(err))
+	return err
+}
+
+// List server hardwares which are unassigned, healthy, powered off and match pool filters
+func (o *Oneview) ListFreeServerHardware() ([]ov.ServerHardware, error) {
+	pool := o.ServerHardwarePool
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	//Get Server Profile infomation
+	serverProfileTemplateName := o.ServerProfileTemplateName
+	serverProfileTemplate, err := ovc.GetProfileTemplateByName(serverProfileTemplateName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if serverProfileTemplate.Name == "" {
+		err := fmt.Errorf("Server profile template %s does not exist", serverProfileTemplateName)
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	// Server hardware type and enclosure group should be same as template
+	filters := []string{
+		fmt.Sprintf("serverHardwareTypeUri='%s'", serverProfileTemplate.ServerHardwareTypeURI),
+	}
+	if serverProfileTemplate.EnclosureGroupURI != "" {
+		filters = append(filters, fmt.Sprintf("serverGroupUri='%s'", serverProfileTemplate.EnclosureGroupURI))
+	}
+	hardwareList, err := ovc.GetServerHardwareList(filters, "name:asc", "", "", "")
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	var enclosureUri string
+	if pool.Enclosure != "" {
+		enclosure, err := ovc.GetEnclosureByName(pool.Enclosure)
+		if err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+		if enclosure.Name == "" {
+			err := fmt.Errorf("Enclosure %s does not exist", pool.Enclosure)
+			log.Error(Wrap(err))
+			return nil, err
+		}
+		enclosureUri = enclosure.URI.String()
+	}
+
+	var scopeUri string
+	if pool.Scope != "" {
+		scope, err := ovc.GetScopeByName(pool.Scope)
+		if err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+		if scope.Name == "" {
+			err := fmt.Errorf("Scope %s does not exist", pool.Scope)
+			log.Error(Wrap(err))
+			return nil, err
+		}
+		scopeUri = scope.URI.String()
+	}
+
+	var candidates []ov.ServerHardware
+	for _, hardware := range hardwareList.Members {
+		if !isFreeServerHardware(hardware) {
+			log.Debugf("%s is not free: state=%s status=%s power=%s", hardware.Name, hardware.State, hardware.Status, hardware.PowerState)
+			continue
+		}
+		if enclosureUri != "" && hardware.LocationURI.String() != enclosureUri {
+			log.Debugf("%s is not in enclosure %s", hardware.Name, pool.Enclosure)
+			continue
+		}
+		if scopeUri != "" {
+			scopeUris, err := getResourceScopes(ovc, hardware)
+			if err != nil {
+				log.Error(Wrap(err))
+				return nil, err
+			}
+			if !containsString(scopeUris, scopeUri) {
+				log.Debugf("%s is not in scope %s", hardware.Name, pool.Scope)
+				continue
+			}
+		}
+		if len(pool.Labels) > 0 {
+			labels, err := getResourceLabels(ovc, hardware.URI.String())
+			if err != nil {
+				log.Error(Wrap(err))
+				return nil, err
+			}
+			if !containsAllStrings(labels, pool.Labels) {
+				log.Debugf("%s does not have labels %v", hardware.Name, pool.Labels)
+				continue
+			}
+		}
+		candidates = append(candidates, hardware)
+	}
+	log.Debugf("Free server hardwares: %d", len(candidates))
+
+	return candidates, nil
+}
+
+func isFreeServerHardware(hardware ov.ServerHardware) bool {
+	return ov.H_NOPROFILE_APPLIED.Equal(hardware.State) &&
+		hardware.ServerProfileURI.IsNil() &&
+		hardware.Status == "OK" &&
+		ov.P_OFF.Equal(hardware.PowerState)
+}
+
+// Get labels assigned to OneView resource
+func getResourceLabelSet(ovc *ov.OVClient, resourceUri string) (*ovResourceLabels, error) {
+	uri := fmt.Sprintf("/rest/labels/resources%s", resourceUri)
+	ovc.RefreshLogin()
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(rest.GET, uri, nil)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	var labels ovResourceLabels
+	if err := json.Unmarshal(data, &labels); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	if labels.ResourceUri == "" {
+		labels.ResourceUri = resourceUri
+	}
+	return &labels, nil
+}
+
+// Get label names of OneView resource
+func getResourceLabels(ovc *ov.OVClient, resourceUri string) ([]string, error) {
+	labels, err := getResourceLabelSet(ovc, resourceUri)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	var names []string
+	for _, label := range labels.Labels {
+		names = append(names, label.Name)
+	}
+	return names, nil
+}
+
+// Get scope URIs which server hardware belongs to
+func getResourceScopes(ovc *ov.OVClient, hardware ov.ServerHardware) ([]string, error) {
+	uri := hardware.ScopesUri
+	if uri == "" {
+		uri = fmt.Sprintf("/rest/scopes/resources%s", hardware.URI)
+	}
+	ovc.RefreshLogin()
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(rest.GET, uri, nil)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+
+	var scopes ovResourceScopes
+	if err := json.Unmarshal(data, &scopes); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	return scopes.ScopeUris, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAllStrings(list []string, targets []string) bool {
+	for _, target := range targets {
+		if !containsString(list, target) {
+			return false
+		}
+	}
+	return true
+}
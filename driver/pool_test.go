@@ -0,0 +1,42 @@
+package driver
+
+import (
+	"testing"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+)
+
+func TestPoolIsFreeServerHardware(t *testing.T) {
+	free := ov.ServerHardware{
+		Name:       "SGH652SV73, bay 5",
+		State:      "NoProfileApplied",
+		Status:     "OK",
+		PowerState: "Off",
+	}
+	if !isFreeServerHardware(free) {
+		t.Fatalf("%s should be free", free.Name)
+	}
+
+	assigned := free
+	assigned.State = "ProfileApplied"
+	assigned.ServerProfileURI = "/rest/server-profiles/9979b3a4-646a-4c3e-bca6-80ca0b403a93"
+	critical := free
+	critical.Status = "Critical"
+	poweredOn := free
+	poweredOn.PowerState = "On"
+	for _, hardware := range []ov.ServerHardware{assigned, critical, poweredOn} {
+		if isFreeServerHardware(hardware) {
+			t.Fatalf("Server hardware should not be free: %#v", hardware)
+		}
+	}
+}
+
+func TestPoolContainsAllStrings(t *testing.T) {
+	labels := []string{"rancher", "worker", "gen10"}
+	if !containsAllStrings(labels, []string{"worker", "rancher"}) {
+		t.Fatal("All labels should be found")
+	}
+	if containsAllStrings(labels, []string{"worker", "master"}) {
+		t.Fatal("Missing label is not detected")
+	}
+}
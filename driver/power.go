@@ -0,0 +1,159 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	log "github.com/docker/machine/libmachine/log"
+	"github.com/docker/machine/libmachine/state"
+)
+
+const (
+	defaultPowerTimeout = 300 //sec
+	resetTypeWarm       = "warm"
+	resetTypeCold       = "cold"
+)
+
+// Power state and status values of HPE OneView
+const (
+	ovPowerStatePoweringOn  = "PoweringOn"
+	ovPowerStatePoweringOff = "PoweringOff"
+	ovPowerStateResetting   = "Resetting"
+	ovStatusCritical        = "Critical"
+)
+
+// Interval to check power state of server hardware
+var powerStateInterval = 5 * time.Second
+
+type ovPowerRequest struct {
+	PowerState   string `json:"powerState"`
+	PowerControl string `json:"powerControl"`
+}
+
+// Request power operation to server hardware and wait until it reaches target power state.
+// Reset is waited by its task.
+func (o *Oneview) setPowerState(target ov.PowerState, control ov.PowerControl) error {
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	//Get Server hardware infomation
+	hardwareName := o.ServerHardwareName
+	hardware, err := ovc.GetServerHardwareByName(hardwareName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if hardware.Name == "" {
+		err := fmt.Errorf("Server hardware %s does not exist", hardwareName)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Reset is requested on powered on server
+	isReset := control == ov.P_RESET || control == ov.P_COLDBOOT
+	if !isReset && target.Equal(hardware.PowerState) {
+		log.Infof("%s is already powered %s", hardwareName, target)
+		return nil
+	}
+
+	log.Infof("Request %s (%s) to %s", control, target, hardwareName)
+	body := ovPowerRequest{
+		PowerState:   target.String(),
+		PowerControl: control.String(),
+	}
+	ovc.RefreshLogin()
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(rest.PUT, hardware.URI.String()+"/powerState", body)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	var task ov.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if task.URI != "" {
+		if err := o.newTaskWatcher(ovc).Wait(task.URI.String()); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	// Power state of reset server stays or returns to On, so only completed task confirms reset
+	if isReset {
+		if task.URI == "" {
+			err := fmt.Errorf("HPE OneView did not return task of %s to %s", control, hardwareName)
+			log.Error(Wrap(err))
+			return err
+		}
+		log.Infof("%s is reset", hardwareName)
+		return nil
+	}
+
+	return o.waitPowerState(ovc, hardware, target)
+}
+
+// Poll server hardware until power state becomes target
+func (o *Oneview) waitPowerState(ovc *ov.OVClient, hardware ov.ServerHardware, target ov.PowerState) error {
+	timeout := time.Duration(o.PowerTimeout) * time.Second
+	if o.PowerTimeout <= 0 {
+		timeout = defaultPowerTimeout * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := ovc.GetServerHardwareByUri(hardware.URI)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		log.Debugf("Power state of %s is %s", hardware.Name, current.PowerState)
+		if target.Equal(current.PowerState) {
+			log.Infof("%s is powered %s", hardware.Name, target)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			err := fmt.Errorf("%s did not reach power state %s in %v. Current power state is %s", hardware.Name, target, timeout, current.PowerState)
+			log.Error(Wrap(err))
+			return err
+		}
+		time.Sleep(powerStateInterval)
+	}
+}
+
+// Convert power state of server hardware to docker-machine state
+func powerStateOf(powerState string) (state.State, error) {
+	switch {
+	case ov.P_ON.Equal(powerState):
+		return state.Running, nil
+	case ov.P_OFF.Equal(powerState):
+		return state.Stopped, nil
+	case powerState == ovPowerStatePoweringOn, powerState == ovPowerStateResetting:
+		return state.Starting, nil
+	case powerState == ovPowerStatePoweringOff:
+		return state.Stopping, nil
+	default:
+		err := fmt.Errorf("Unknown power state: %s", powerState)
+		return state.Error, err
+	}
+}
+
+// Power control of reset type
+func resetControl(resetType string) (ov.PowerControl, error) {
+	switch strings.ToLower(resetType) {
+	case "", resetTypeWarm:
+		return ov.P_RESET, nil
+	case resetTypeCold:
+		return ov.P_COLDBOOT, nil
+	default:
+		err := fmt.Errorf("Unknown reset type: %s. Specify %s or %s", resetType, resetTypeWarm, resetTypeCold)
+		return 0, err
+	}
+}
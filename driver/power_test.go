@@ -0,0 +1,207 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/machine/libmachine/state"
+)
+
+// Fake HPE OneView which changes power state of one server hardware
+type testPowerServer struct {
+	sync.Mutex
+	powerState string
+	status     string
+	profile    string // Server profile json
+	template   string // Server profile template json
+	tasks      string // Running tasks json
+	creations  int    // Requests to create server profile
+	task       string // Task json returned for power request
+	requests   []ovPowerRequest
+	ignore     bool // Server does not react to power button
+	server     *httptest.Server
+}
+
+func createTestPowerServer(powerState string) *testPowerServer {
+	s := &testPowerServer{powerState: powerState, status: "OK"}
+	hardware := func() string {
+		return fmt.Sprintf(`{"name":"bay1","uri":"/rest/server-hardware/bay1","powerState":"%s","status":"%s"}`, s.powerState, s.status)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		switch {
+		case r.URL.Path == "/rest/version":
+			fmt.Fprint(w, `{"currentVersion":2400,"minimumVersion":120}`)
+		case r.URL.Path == "/rest/login-sessions":
+			fmt.Fprint(w, `{"sessionID":"power-session"}`)
+		case r.URL.Path == "/rest/sessions/idle-timeout":
+			fmt.Fprint(w, `{"idleTimeout":86400000}`)
+		case r.URL.Path == "/rest/server-hardware":
+			fmt.Fprintf(w, `{"total":1,"count":1,"members":[%s]}`, hardware())
+		case r.URL.Path == "/rest/server-hardware/bay1/powerState":
+			var req ovPowerRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			s.requests = append(s.requests, req)
+			if !s.ignore {
+				s.powerState = req.PowerState
+			}
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"uri":"/rest/tasks/power","taskState":"Running"}`)
+		case r.URL.Path == "/rest/server-profiles" && r.Method == http.MethodPost:
+			s.creations++
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"uri":"/rest/tasks/profile","taskState":"Running"}`)
+		case r.URL.Path == "/rest/server-profile-templates":
+			if s.template == "" {
+				fmt.Fprint(w, `{"total":0,"count":0,"members":[]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"total":1,"count":1,"members":[%s]}`, s.template)
+		case r.URL.Path == "/rest/tasks":
+			fmt.Fprintf(w, `{"members":[%s]}`, s.tasks)
+		case r.URL.Path == "/rest/server-profiles":
+			if s.profile == "" {
+				fmt.Fprint(w, `{"total":0,"count":0,"members":[]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"total":1,"count":1,"members":[%s]}`, s.profile)
+		case r.URL.Path == "/rest/server-hardware/bay1":
+			fmt.Fprint(w, hardware())
+		case strings.HasPrefix(r.URL.Path, "/rest/tasks/"):
+			if s.task != "" {
+				fmt.Fprint(w, s.task)
+				return
+			}
+			fmt.Fprint(w, `{"name":"Power","taskState":"Completed","percentComplete":100}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func createTestPowerOneview(t *testing.T, endpoint string) *Oneview {
+	interval := powerStateInterval
+	t.Cleanup(func() { powerStateInterval = interval })
+	powerStateInterval = 10 * time.Millisecond
+	return &Oneview{
+		Endpoint:           endpoint,
+		ApiVersion:         1200,
+		Username:           "rancher",
+		Password:           "password",
+		ServerHardwareName: "bay1",
+		PowerTimeout:       1,
+	}
+}
+
+func TestPowerControl(t *testing.T) {
+	s := createTestPowerServer("On")
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+
+	if err := o.ShutDown(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.PowerOn(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.PowerOff(); err != nil {
+		t.Fatal(err)
+	}
+	// Powered off server is powered on by reset
+	if err := o.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	o.ResetType = resetTypeCold
+	if err := o.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ovPowerRequest{
+		{PowerState: "Off", PowerControl: "MomentaryPress"},
+		{PowerState: "On", PowerControl: "MomentaryPress"},
+		{PowerState: "Off", PowerControl: "PressAndHold"},
+		{PowerState: "On", PowerControl: "MomentaryPress"},
+		{PowerState: "On", PowerControl: "ColdBoot"},
+	}
+	if fmt.Sprint(s.requests) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected power requests: %v", s.requests)
+	}
+}
+
+func TestPowerResetTask(t *testing.T) {
+	s := createTestPowerServer("On")
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+
+	// Reset is confirmed by task, not by power state which is On before and after reset
+	s.task = `{"name":"Reset","taskState":"Error","percentComplete":100,"taskErrors":[{"message":"iLO is not responding"}]}`
+	if err := o.Reset(); err == nil {
+		t.Fatal("Reset should fail when task fails")
+	}
+	s.task = ""
+	if err := o.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.requests) != 2 {
+		t.Errorf("Unexpected power requests: %v", s.requests)
+	}
+}
+
+func TestPowerTimeout(t *testing.T) {
+	s := createTestPowerServer("On")
+	s.ignore = true
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+
+	if err := o.ShutDown(); err == nil {
+		t.Fatal("Error should be returned when server is not powered off")
+	}
+}
+
+func TestPowerResetControl(t *testing.T) {
+	if _, err := resetControl("hot"); err == nil {
+		t.Errorf("Unknown reset type should be error")
+	}
+}
+
+func TestPowerGetState(t *testing.T) {
+	s := createTestPowerServer("On")
+	defer s.server.Close()
+	o := createTestPowerOneview(t, s.server.URL)
+	o.ServerProfileName = "profile1"
+
+	cases := []struct {
+		powerState string
+		status     string
+		profile    string
+		expected   state.State
+		isError    bool
+	}{
+		{powerState: "On", status: "OK", expected: state.Running},
+		{powerState: "Off", status: "Warning", expected: state.Stopped},
+		{powerState: "PoweringOn", status: "OK", expected: state.Starting},
+		{powerState: "Resetting", status: "OK", expected: state.Starting},
+		{powerState: "PoweringOff", status: "OK", expected: state.Stopping},
+		{powerState: "Unknown", status: "OK", expected: state.Error, isError: true},
+		{powerState: "On", status: "Critical", expected: state.Error, isError: true},
+		{powerState: "On", status: "OK", profile: `{"name":"profile1","state":"Normal","status":"OK"}`, expected: state.Running},
+		{powerState: "On", status: "OK", profile: `{"name":"profile1","state":"UpdateFailed","status":"Critical"}`, expected: state.Error, isError: true},
+	}
+	for _, c := range cases {
+		s.Lock()
+		s.powerState, s.status, s.profile = c.powerState, c.status, c.profile
+		s.Unlock()
+		serverState, err := o.GetState()
+		if serverState != c.expected || (err != nil) != c.isError {
+			t.Errorf("%s/%s/%s: expected %s, got %s (%v)", c.powerState, c.status, c.profile, c.expected, serverState, err)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package driver
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	defaultCatalog = "boot.catalog"
+)
+
+// Boot configurations of Red Hat based and Ubuntu installer which are patched
+var (
+	isolinuxCfgPaths = []string{"isolinux/isolinux.cfg", "isolinux/txt.cfg"}
+	grubCfgPaths     = []string{"EFI/BOOT/grub.cfg", "boot/grub2/grub.cfg", "boot/grub/grub.cfg"}
+)
+
+var (
+	bootArgStage2   = regexp.MustCompile(`inst\.stage2=hd:LABEL=\S+`)
+	bootArgKs       = regexp.MustCompile(`\s+(inst\.)?ks=\S+`)
+	bootArgCasper   = regexp.MustCompile(`/casper/(hwe-)?vmlinuz`)
+	bootArgAuto     = regexp.MustCompile(`\sautoinstall(\s|$)`)
+	isolinuxTimeout = regexp.MustCompile(`(?m)^(\s*timeout\s+)\d+`)
+	isolinuxLabel   = regexp.MustCompile(`^\s*label\s+`)
+	isolinuxDefault = regexp.MustCompile(`^\s*menu\s+default\s*$`)
+	isolinuxAppend  = regexp.MustCompile(`^\s*append\s`)
+	isolinuxKernel  = regexp.MustCompile(`^\s*kernel\s`)
+	grubTimeout     = regexp.MustCompile(`(?m)^(\s*set\s+timeout=)\S+`)
+	grubDefault     = regexp.MustCompile(`(?m)^(\s*set\s+default=)\S+`)
+	grubMenuEntry   = regexp.MustCompile(`^menuentry\s`)
+	grubLinux       = regexp.MustCompile(`^\s*linux(efi)?\s`)
+)
+
+// Options to remaster installer ISO for kickstart on labeled image
+type RemasterOptions struct {
+	KsLabel     string // Volume label of kickstart image
+	KsUrl       string // URL of kickstart file. Labeled image is not used if specified.
+	VolumeLabel string // Volume label of remastered image. Label of stock image is kept if empty.
+	Timeout     int    // Boot menu timeout in seconds. Stock value is kept if negative.
+}
+
+// Write remastered installer ISO which starts kickstart installation.
+// Boot menus are patched and El Torito BIOS and UEFI boot images are kept.
+func RemasterIso(srcPath, dstPath string, opts *RemasterOptions) error {
+	if opts.KsLabel == "" {
+		opts.KsLabel = defaultKsLabel
+	}
+	src, err := openIsoSource(srcPath)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer src.Close()
+	if len(src.boot) == 0 {
+		err := fmt.Errorf("%s is not bootable ISO image", srcPath)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	label := src.label
+	if opts.VolumeLabel != "" {
+		label = opts.VolumeLabel
+	}
+	log.Infof("Remaster %s (label %s) as %s (label %s)", srcPath, src.label, dstPath, label)
+	img := newIsoImage(label)
+	for _, dir := range src.dirs {
+		img.AddDir(dir)
+	}
+	for _, link := range src.symlinks {
+		if err := img.AddSymlink(link.path, link.target); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	patched := 0
+	for _, f := range src.files {
+		if f.path == src.catalogPath {
+			continue
+		}
+		var patch func(string, string, *RemasterOptions) string
+		for _, isolinuxCfg := range isolinuxCfgPaths {
+			if f.path == isolinuxCfg {
+				patch = patchIsolinuxCfg
+			}
+		}
+		for _, grubCfg := range grubCfgPaths {
+			if f.path == grubCfg {
+				patch = patchGrubCfg
+			}
+		}
+		if patch == nil {
+			if err := img.AddFile(f.path, int64(f.size), src.open(f)); err != nil {
+				log.Error(Wrap(err))
+				return err
+			}
+			continue
+		}
+
+		data, err := src.readAt(f.lba, int(f.size))
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		log.Infof("Patch %s", f.path)
+		if err := img.AddBytes(f.path, []byte(patch(string(data), label, opts))); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		patched++
+	}
+	if patched == 0 {
+		err := fmt.Errorf("Boot configuration is not found in %s", srcPath)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	catalog := src.catalogPath
+	if catalog == "" {
+		catalog = defaultCatalog
+	}
+	for _, e := range src.boot {
+		log.Infof("Keep boot image %s (platform 0x%02x)", e.path, e.platform)
+	}
+	if err := img.SetBoot(catalog, src.boot); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	tmpPath := fmt.Sprintf("%s.%d.tmp", dstPath, os.Getpid())
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	_, err = img.WriteTo(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, dstPath)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Infof("Remastered image %s is written", dstPath)
+	return nil
+}
+
+// Installer kernel of Red Hat based OS or Ubuntu live server
+func isInstallBootLine(line string) bool {
+	return bootArgStage2.MatchString(line) || bootArgCasper.MatchString(line)
+}
+
+// Add kickstart on labeled image or URL and point stage2 to remastered image.
+// Ubuntu installer starts autoinstall with NoCloud seed labeled CIDATA.
+func patchBootArgs(line, label string, opts *RemasterOptions) string {
+	if grubLinux.MatchString(line) && bootArgCasper.MatchString(line) {
+		return addAutoinstallArg(line)
+	}
+	if !bootArgStage2.MatchString(line) {
+		return line
+	}
+	line = bootArgKs.ReplaceAllString(line, "")
+	stage2 := "inst.stage2=hd:LABEL=" + escapeBootLabel(label)
+	ks := fmt.Sprintf("inst.ks=hd:LABEL=%s:/%s", escapeBootLabel(opts.KsLabel), ksFileName)
+	if opts.KsUrl != "" {
+		ks = "inst.ks=" + opts.KsUrl
+	}
+	return bootArgStage2.ReplaceAllLiteralString(line, stage2+" "+ks)
+}
+
+// Parameters after "---" are for installed OS
+func addAutoinstallArg(line string) string {
+	if bootArgAuto.MatchString(line) {
+		return line
+	}
+	if i := strings.Index(line, " ---"); i >= 0 {
+		return line[:i] + " autoinstall" + line[i:]
+	}
+	return line + " autoinstall"
+}
+
+// Spaces in labels are written as \x20 in boot parameters
+func escapeBootLabel(label string) string {
+	return strings.Replace(label, " ", `\x20`, -1)
+}
+
+// Installation is default menu of isolinux
+func patchIsolinuxCfg(cfg, label string, opts *RemasterOptions) string {
+	if opts.Timeout >= 0 {
+		// isolinux timeout is in 1/10 seconds and 0 means no timeout
+		timeout := opts.Timeout * 10
+		if timeout == 0 {
+			timeout = 1
+		}
+		cfg = isolinuxTimeout.ReplaceAllString(cfg, "${1}"+strconv.Itoa(timeout))
+	}
+
+	installName, current := "", ""
+	casper := false // Ubuntu kernel is in kernel line instead of append line
+	var result []string
+	for _, line := range strings.Split(cfg, "\n") {
+		if isolinuxLabel.MatchString(line) {
+			current = isolinuxLabelName(line)
+			casper = false
+		}
+		if isolinuxDefault.MatchString(line) {
+			continue
+		}
+		if isolinuxKernel.MatchString(line) && bootArgCasper.MatchString(line) {
+			casper = true
+		}
+		if isolinuxAppend.MatchString(line) && (casper || isInstallBootLine(line)) {
+			if installName == "" {
+				installName = current
+			}
+			if casper {
+				line = addAutoinstallArg(line)
+			}
+		}
+		result = append(result, patchBootArgs(line, label, opts))
+	}
+
+	// "menu default" is put just after label of first installation entry
+	for i, line := range result {
+		if installName != "" && isolinuxLabel.MatchString(line) && isolinuxLabelName(line) == installName {
+			result = append(result[:i+1], append([]string{"  menu default"}, result[i+1:]...)...)
+			break
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+func isolinuxLabelName(line string) string {
+	return strings.TrimSpace(isolinuxLabel.ReplaceAllString(line, ""))
+}
+
+// First top level installation entry is default menu of grub
+func patchGrubCfg(cfg, label string, opts *RemasterOptions) string {
+	if opts.Timeout >= 0 {
+		cfg = grubTimeout.ReplaceAllString(cfg, "${1}"+strconv.Itoa(opts.Timeout))
+	}
+
+	lines := strings.Split(cfg, "\n")
+	entry := -1
+	installEntry := -1
+	depth := 0
+	for i, line := range lines {
+		if depth == 0 && grubMenuEntry.MatchString(line) {
+			entry++
+		}
+		if grubLinux.MatchString(line) && isInstallBootLine(line) && depth == 1 && installEntry < 0 {
+			installEntry = entry
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		lines[i] = patchBootArgs(line, label, opts)
+	}
+	cfg = strings.Join(lines, "\n")
+	if installEntry >= 0 {
+		cfg = grubDefault.ReplaceAllString(cfg, fmt.Sprintf(`${1}"%d"`, installEntry))
+	}
+	return cfg
+}
@@ -0,0 +1,285 @@
+package driver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testIsolinuxCfg = `default vesamenu.c32
+timeout 600
+
+label linux
+  menu label ^Install CentOS 7
+  kernel vmlinuz
+  append initrd=initrd.img inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 quiet
+
+label check
+  menu label Test this ^media & install CentOS 7
+  menu default
+  kernel vmlinuz
+  append initrd=initrd.img inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 rd.live.check quiet
+`
+
+const testGrubCfg = `set default="1"
+set timeout=60
+
+menuentry 'Install CentOS 7' --class fedora --class gnu-linux --class gnu --class os {
+	linuxefi /images/pxeboot/vmlinuz inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 quiet
+	initrdefi /images/pxeboot/initrd.img
+}
+menuentry 'Test this media & install CentOS 7' --class fedora --class gnu-linux --class gnu --class os {
+	linuxefi /images/pxeboot/vmlinuz inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 rd.live.check quiet
+	initrdefi /images/pxeboot/initrd.img
+}
+submenu 'Troubleshooting -->' {
+	menuentry 'Rescue a CentOS system' {
+		linuxefi /images/pxeboot/vmlinuz inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 rescue quiet
+	}
+}
+`
+
+// Stock installer image like CentOS DVD
+func createTestInstallerIso(t *testing.T, path string) {
+	img := newIsoImage("CentOS 7 x86_64")
+	isolinuxBin := bytes.Repeat([]byte{0x90}, 5000)
+	img.AddBytes("isolinux/isolinux.bin", isolinuxBin)
+	img.AddBytes("isolinux/isolinux.cfg", []byte(testIsolinuxCfg))
+	img.AddBytes("EFI/BOOT/grub.cfg", []byte(testGrubCfg))
+	img.AddBytes("EFI/BOOT/BOOTX64.EFI", []byte("efi"))
+	img.AddBytes("images/efiboot.img", bytes.Repeat([]byte{0xef}, 4096))
+	img.AddBytes("Packages/kernel-3.10.0-1160.el7.x86_64.rpm", []byte("rpm"))
+	if err := img.SetBoot("isolinux/boot.cat", []*isoBootEntry{
+		{platform: isoBootPlatformX86, sectorCount: 4, path: "isolinux/isolinux.bin", bootInfoTable: true},
+		{platform: isoBootPlatformEfi, sectorCount: 8, path: "images/efiboot.img"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := img.WriteTo(f); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestIsoFile(t *testing.T, src *isoSource, path string) []byte {
+	for _, f := range src.files {
+		if f.path == path {
+			data, err := src.readAt(f.lba, int(f.size))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return data
+		}
+	}
+	t.Fatalf("%s is not found in image", path)
+	return nil
+}
+
+func TestRemasterIso(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ov-remaster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	srcPath := filepath.Join(dir, "CentOS-7-x86_64-DVD.iso")
+	dstPath := filepath.Join(dir, "CentOS-7-x86_64-ks.iso")
+	createTestInstallerIso(t, srcPath)
+
+	if err := RemasterIso(srcPath, dstPath, &RemasterOptions{KsLabel: "ov-ks", Timeout: 5}); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := openIsoSource(dstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	if dst.label != "CentOS 7 x86_64" {
+		t.Errorf("Volume label should be kept: %s", dst.label)
+	}
+	if !bytes.Equal(readTestIsoFile(t, dst, "Packages/kernel-3.10.0-1160.el7.x86_64.rpm"), []byte("rpm")) {
+		t.Error("Other files should be copied")
+	}
+
+	isolinux := string(readTestIsoFile(t, dst, "isolinux/isolinux.cfg"))
+	for _, expected := range []string{
+		"timeout 50\n",
+		"label linux\n  menu default\n",
+		`append initrd=initrd.img inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 inst.ks=hd:LABEL=ov-ks:/ks.cfg quiet`,
+	} {
+		if !strings.Contains(isolinux, expected) {
+			t.Errorf("isolinux.cfg does not contain %q:\n%s", expected, isolinux)
+		}
+	}
+	if strings.Count(isolinux, "menu default") != 1 {
+		t.Errorf("Only installation should be default:\n%s", isolinux)
+	}
+
+	grub := string(readTestIsoFile(t, dst, "EFI/BOOT/grub.cfg"))
+	for _, expected := range []string{
+		`set default="0"`,
+		"set timeout=5\n",
+		`linuxefi /images/pxeboot/vmlinuz inst.stage2=hd:LABEL=CentOS\x207\x20x86_64 inst.ks=hd:LABEL=ov-ks:/ks.cfg quiet`,
+	} {
+		if !strings.Contains(grub, expected) {
+			t.Errorf("grub.cfg does not contain %q:\n%s", expected, grub)
+		}
+	}
+
+	// BIOS and UEFI boot images are kept
+	if len(dst.boot) != 2 || dst.boot[0].path != "isolinux/isolinux.bin" || dst.boot[0].platform != isoBootPlatformX86 ||
+		dst.boot[1].path != "images/efiboot.img" || dst.boot[1].platform != isoBootPlatformEfi || dst.boot[1].sectorCount != 8 {
+		t.Fatalf("Unexpected boot entries: %+v %+v", dst.boot[0], dst.boot[len(dst.boot)-1])
+	}
+	if !dst.boot[0].bootInfoTable {
+		t.Error("Boot info table should be patched")
+	}
+	isolinuxBin := readTestIsoFile(t, dst, "isolinux/isolinux.bin")
+	if binary.LittleEndian.Uint32(isolinuxBin[16:20]) != 5000 || !bytes.Equal(isolinuxBin[64:], bytes.Repeat([]byte{0x90}, 5000-64)) {
+		t.Error("Unexpected boot info table")
+	}
+}
+
+func TestRemasterVolumeLabel(t *testing.T) {
+	opts := &RemasterOptions{KsLabel: "my ks", Timeout: -1}
+	cfg := patchGrubCfg(testGrubCfg, "CentOS-7-ks", opts)
+	if !strings.Contains(cfg, `inst.stage2=hd:LABEL=CentOS-7-ks inst.ks=hd:LABEL=my\x20ks:/ks.cfg rd.live.check`) {
+		t.Errorf("Labels are not patched:\n%s", cfg)
+	}
+	if !strings.Contains(cfg, "set timeout=60\n") {
+		t.Errorf("Timeout should be kept:\n%s", cfg)
+	}
+
+	// Existing kickstart parameter is replaced
+	line := patchBootArgs("append inst.ks=http://web/ks.cfg inst.stage2=hd:LABEL=A quiet", "B", opts)
+	if line != `append inst.stage2=hd:LABEL=B inst.ks=hd:LABEL=my\x20ks:/ks.cfg quiet` {
+		t.Errorf("Unexpected boot parameters: %s", line)
+	}
+
+	// Kickstart URL is used for UEFI HTTP boot
+	opts.KsUrl = "http://192.168.1.5:8080/192.168.1.10-ks.cfg"
+	line = patchBootArgs("append inst.stage2=hd:LABEL=A quiet", "B", opts)
+	if line != `append inst.stage2=hd:LABEL=B inst.ks=http://192.168.1.5:8080/192.168.1.10-ks.cfg quiet` {
+		t.Errorf("Unexpected boot parameters: %s", line)
+	}
+}
+
+func TestRemasterUbuntu(t *testing.T) {
+	grub := `set timeout=30
+menuentry "Try or Install Ubuntu Server" {
+	set gfxpayload=keep
+	linux	/casper/vmlinuz  ---
+	initrd	/casper/initrd
+}
+menuentry "Ubuntu Server with the HWE kernel" {
+	linux	/casper/hwe-vmlinuz autoinstall ---
+	initrd	/casper/hwe-initrd
+}
+`
+	cfg := patchGrubCfg(grub, "Ubuntu-Server 22.04 LTS amd64", &RemasterOptions{Timeout: 1})
+	if !strings.Contains(cfg, "linux	/casper/vmlinuz  autoinstall ---\n") || strings.Count(cfg, "autoinstall") != 2 {
+		t.Errorf("autoinstall is not added:\n%s", cfg)
+	}
+
+	isolinux := `default live
+label live
+  menu label ^Install Ubuntu Server
+  kernel /casper/vmlinuz
+  append   initrd=/casper/initrd quiet  ---
+label memtest
+  kernel /install/mt86plus
+`
+	cfg = patchIsolinuxCfg(isolinux, "Ubuntu-Server 20.04", &RemasterOptions{Timeout: 1})
+	if !strings.Contains(cfg, "label live\n  menu default\n") || !strings.Contains(cfg, "append   initrd=/casper/initrd quiet  autoinstall ---") || !strings.Contains(cfg, "kernel /casper/vmlinuz\n") {
+		t.Errorf("autoinstall is not added:\n%s", cfg)
+	}
+}
+
+// Extract image built by external tool. See testdata/ubuntu-rr.sh
+func extractTestIso(t *testing.T, name, dir string) string {
+	f, err := os.Open(filepath.Join("testdata", name+".gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, r); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testIsoSymlinks(src *isoSource) map[string]string {
+	links := map[string]string{}
+	for _, link := range src.symlinks {
+		links[link.path] = link.target
+	}
+	return links
+}
+
+func TestRemasterSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ov-remaster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	srcPath := extractTestIso(t, "ubuntu-rr.iso", dir)
+	dstPath := filepath.Join(dir, "ubuntu-ks.iso")
+
+	expected := map[string]string{
+		"ubuntu":                   ".",
+		"dists/stable":             "focal",
+		"dists/unstable-long-name": "focal",
+		"boot/vmlinuz":             "../casper/vmlinuz",
+		"isolinux/initrd-abs":      "/casper/initrd",
+	}
+	src, err := openIsoSource(srcPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	if links := testIsoSymlinks(src); !reflect.DeepEqual(links, expected) {
+		t.Errorf("Unexpected symbolic links in source image: %v", links)
+	}
+
+	if err := RemasterIso(srcPath, dstPath, &RemasterOptions{Timeout: 1}); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := openIsoSource(dstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	if links := testIsoSymlinks(dst); !reflect.DeepEqual(links, expected) {
+		t.Errorf("Symbolic links should be kept: %v", links)
+	}
+	if !bytes.Equal(readTestIsoFile(t, dst, "dists/focal/Release"), []byte("Suite: focal\n")) {
+		t.Error("Other files should be copied")
+	}
+	if cfg := string(readTestIsoFile(t, dst, "isolinux/isolinux.cfg")); !strings.Contains(cfg, "autoinstall ---") {
+		t.Errorf("autoinstall is not added:\n%s", cfg)
+	}
+	if len(dst.boot) != 1 || dst.boot[0].path != "isolinux/isolinux.bin" || !dst.boot[0].bootInfoTable {
+		t.Errorf("Unexpected boot entries: %+v", dst.boot)
+	}
+}
@@ -0,0 +1,158 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	machineConfigFileName = "config.json"
+)
+
+// Reinstall OS on same server hardware.
+// Server profile is reused, so connections and MAC addresses are kept.
+// IP address and hostname in saved config are not changed.
+func (d *Driver) Reprovision() error {
+	log.Infof("Reprovision %s on %s", d.GetMachineName(), d.HpeConfig.Oneview.ServerHardwareName)
+
+	log.Info("Check server profile on HPE OneView")
+	if err := d.HpeConfig.Oneview.CheckServerProfile(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	if err := d.startHttpServer(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer d.stopHttpServer()
+
+	// Same key pair saved in machine directory is embedded again
+	if err := d.generateKickstart(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer d.HpeConfig.Server.RemoveKickstart()
+
+	// Media of previous installation may be still inserted
+	if err := d.ejectInstallMedia(); err != nil {
+		log.Debugf("Eject virtual media before reprovisioning: %v", err)
+	}
+	if err := d.insertInstallMedia(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	// Installation media should not be left even if reprovisioning fails
+	defer d.ejectInstallMedia()
+
+	if err := d.setOneTimeBoot(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	log.Info("Restart server to boot from installation media")
+	if err := d.HpeConfig.Oneview.Reset(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	// Reinstalled OS is not hardened yet
+	d.HpeConfig.Server.SshHardened = false
+	d.BaseDriver.SSHUser = d.HpeConfig.Server.sshUser()
+	d.BaseDriver.SSHPort = d.HpeConfig.Server.sshPort()
+
+	// Same key pair saved in machine directory is used for login and registered again
+	if err := d.genSshKeyPairs(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	log.Info("Start OS installation")
+	if err := d.HpeConfig.Server.WaitOsInstallation(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	log.Info("Copy ssh keys")
+	if err := d.HpeConfig.Server.CopySshPubKey(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := d.hardenSsh(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	log.Info("Server reprovisioning has been done!")
+	return nil
+}
+
+func machineConfigPath(storePath, machineName string) string {
+	return filepath.Join(storePath, "machines", machineName, machineConfigFileName)
+}
+
+// Read saved machine of this driver from docker-machine store
+func loadMachine(storePath, machineName string) (map[string]json.RawMessage, *Driver, error) {
+	bytes, err := ioutil.ReadFile(machineConfigPath(storePath, machineName))
+	if err != nil {
+		return nil, nil, err
+	}
+	var machine map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &machine); err != nil {
+		return nil, nil, err
+	}
+	var name string
+	if err := json.Unmarshal(machine["DriverName"], &name); err != nil || name != driverName {
+		return nil, nil, fmt.Errorf("%s is not created by %s driver", machineName, driverName)
+	}
+
+	d := NewDriver(machineName, storePath)
+	if err := json.Unmarshal(machine["Driver"], d); err != nil {
+		return nil, nil, err
+	}
+	if d.HpeConfig == nil || d.HpeConfig.Oneview == nil || d.HpeConfig.Server == nil {
+		return nil, nil, fmt.Errorf("Driver config of %s is broken", machineName)
+	}
+	return machine, d, nil
+}
+
+// Load saved machine from docker-machine store, reprovision it and save driver state again
+func ReprovisionMachine(storePath, machineName string) error {
+	configPath := machineConfigPath(storePath, machineName)
+	machine, d, err := loadMachine(storePath, machineName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	reprovisionErr := d.Reprovision()
+
+	// Driver state such as TLS fingerprints may be updated
+	driverBytes, err := json.Marshal(d)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	machine["Driver"] = driverBytes
+	bytes, err := json.MarshalIndent(machine, "", "    ")
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	tmpPath := fmt.Sprintf("%s.%d.tmp", configPath, os.Getpid())
+	if err := ioutil.WriteFile(tmpPath, bytes, 0600); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := os.Rename(tmpPath, configPath); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	return reprovisionErr
+}
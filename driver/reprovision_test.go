@@ -0,0 +1,97 @@
+package driver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestMachineConfig(t *testing.T, storePath, machineName string, machine map[string]interface{}) string {
+	dir := filepath.Join(storePath, "machines", machineName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	bytes, err := json.Marshal(machine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, machineConfigFileName)
+	if err := ioutil.WriteFile(path, bytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReprovisionMachineOtherDriver(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "ov-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(storePath)
+
+	writeTestMachineConfig(t, storePath, "vm1", map[string]interface{}{
+		"DriverName": "virtualbox",
+		"Driver":     map[string]interface{}{},
+	})
+	if err := ReprovisionMachine(storePath, "vm1"); err == nil {
+		t.Fatal("Machine of other driver should not be reprovisioned")
+	}
+}
+
+func TestReprovisionMachineKeepsConfig(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "ov-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(storePath)
+
+	// Server profile does not exist on fake HPE OneView
+	s := createTestPowerServer("On")
+	defer s.server.Close()
+
+	path := writeTestMachineConfig(t, storePath, "node1", map[string]interface{}{
+		"ConfigVersion": 3,
+		"DriverName":    driverName,
+		"Name":          "node1",
+		"Driver": map[string]interface{}{
+			"IPAddress":   "172.16.14.10",
+			"MachineName": "node1",
+			"StorePath":   storePath,
+			"Oneview": map[string]interface{}{
+				"Endpoint":           s.server.URL,
+				"ApiVersion":         1200,
+				"ServerProfileName":  "ov-docker-machine-node1",
+				"ServerHardwareName": "bay1",
+			},
+			"Server": map[string]interface{}{
+				"Address":  "172.16.14.10",
+				"Hostname": "node1",
+			},
+		},
+	})
+	if err := ReprovisionMachine(storePath, "node1"); err == nil {
+		t.Fatal("Reprovisioning without server profile should be error")
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var machine struct {
+		ConfigVersion int
+		Name          string
+		Driver        *Driver
+	}
+	machine.Driver = NewDriver("", "")
+	if err := json.Unmarshal(bytes, &machine); err != nil {
+		t.Fatal(err)
+	}
+	if machine.ConfigVersion != 3 || machine.Name != "node1" {
+		t.Errorf("Machine config is not kept: %s", bytes)
+	}
+	if machine.Driver.IPAddress != "172.16.14.10" || machine.Driver.HpeConfig.Server.Hostname != "node1" {
+		t.Errorf("IP address and hostname should be kept: %s", bytes)
+	}
+}
@@ -0,0 +1,366 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	reservationDirName      = "ov-reservations"
+	reservationLabelPrefix  = "ov-reserved-by-"
+	reservationGuardName    = ".guard"
+	reservationGuardTimeout = 30 //sec
+	releaseLabelRetry       = 3
+)
+
+// Interval to retry locking guard of lock files
+var reservationGuardInterval = 100 * time.Millisecond
+
+var lockFileNameReplacer = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// Lock file to reserve server hardware between driver processes sharing same docker-machine store
+type HardwareLock struct {
+	Path      string
+	StorePath string
+	Owner     string
+}
+
+func NewHardwareLock(storePath, hardwareName, owner string) *HardwareLock {
+	fileName := lockFileNameReplacer.ReplaceAllString(hardwareName, "_") + ".lock"
+	return &HardwareLock{
+		Path:      filepath.Join(storePath, reservationDirName, fileName),
+		StorePath: storePath,
+		Owner:     owner,
+	}
+}
+
+// Guard file is locked while lock file is checked and replaced,
+// so stale lock file is not removed after other process has replaced it.
+func (l *HardwareLock) lockGuard() (*fileLock, error) {
+	dir := filepath.Dir(l.Path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return lockFile(filepath.Join(dir, reservationGuardName), reservationGuardTimeout*time.Second, reservationGuardInterval)
+}
+
+// Create lock file exclusively.
+// Lock file of owner itself is accepted to resume provisioning.
+func (l *HardwareLock) Acquire() error {
+	guard, err := l.lockGuard()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer guard.Unlock()
+
+	owner, err := l.currentOwner()
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		log.Error(Wrap(err))
+		return err
+	case owner == l.Owner:
+		return nil
+	case !l.isStale(owner):
+		return fmt.Errorf("Server hardware is reserved by %s", owner)
+	default:
+		log.Warnf("Replace stale lock file %s of %s", l.Path, owner)
+	}
+
+	if err := ioutil.WriteFile(l.Path, []byte(l.Owner), 0600); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Debugf("Lock file %s is created by %s", l.Path, l.Owner)
+	return nil
+}
+
+// Delete lock file when owner has it
+func (l *HardwareLock) Release() error {
+	guard, err := l.lockGuard()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	defer guard.Unlock()
+
+	owner, err := l.currentOwner()
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if owner != l.Owner {
+		log.Warnf("Lock file %s is owned by %s. Skip to release", l.Path, owner)
+		return nil
+	}
+	if err := os.Remove(l.Path); err != nil && !os.IsNotExist(err) {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+func (l *HardwareLock) currentOwner() (string, error) {
+	bytes, err := ioutil.ReadFile(l.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
+
+// Lock file is stale when owner machine has been removed from store
+func (l *HardwareLock) isStale(owner string) bool {
+	if owner == "" {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(l.StorePath, "machines", owner))
+	return os.IsNotExist(err)
+}
+
+// Put reservation label on server hardware.
+// This prevents other docker-machine stores from using same server hardware.
+func (o *Oneview) ReserveServerHardware(hardwareName string) error {
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	hardware, err := ovc.GetServerHardwareByName(hardwareName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if hardware.Name == "" {
+		err := fmt.Errorf("Server hardware %s does not exist", hardwareName)
+		log.Error(Wrap(err))
+		return err
+	}
+
+	myLabel := reservationLabelPrefix + o.ServerProfileName
+	labels, err := getResourceLabelSet(ovc, hardware.URI.String())
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	for owner := reservationOwner(labels.Labels, myLabel); owner != ""; owner = reservationOwner(labels.Labels, myLabel) {
+		stale, err := isStaleReservation(ovc, hardware, owner)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if !stale {
+			err := fmt.Errorf("Server hardware %s is reserved by %s", hardwareName, owner)
+			return err
+		}
+		// Label is replaced below with eTag, so only one process reclaims it
+		log.Warnf("Reservation of %s by %s is stale. Server profile %s does not exist", hardwareName, owner, owner)
+		labels.Labels = removeLabel(labels.Labels, reservationLabelPrefix+owner)
+	}
+	if !hasLabel(labels.Labels, myLabel) {
+		log.Infof("Put reservation label %s on %s", myLabel, hardwareName)
+		labels.Labels = append(labels.Labels, ovLabel{Name: myLabel})
+		// Labels have been updated by other process since they were read
+		if err := putResourceLabels(ovc, labels); isLabelConflict(err) {
+			return fmt.Errorf("Server hardware %s is reserved by other process at the same time", hardwareName)
+		} else if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	// Check again in case appliance does not return eTag of labels
+	labels, err = getResourceLabelSet(ovc, hardware.URI.String())
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if owner := reservationOwner(labels.Labels, myLabel); owner != "" || !hasLabel(labels.Labels, myLabel) {
+		o.releaseReservationLabel(ovc, hardware)
+		err := fmt.Errorf("Server hardware %s is reserved by other process at the same time", hardwareName)
+		return err
+	}
+	return nil
+}
+
+// Remove reservation label from server hardware
+func (o *Oneview) ReleaseServerHardware(hardwareName string) error {
+	ovc, err := o.NewClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+
+	hardware, err := ovc.GetServerHardwareByName(hardwareName)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if hardware.Name == "" {
+		log.Warnf("Server hardware %s does not exist. Skip to release", hardwareName)
+		return nil
+	}
+	return o.releaseReservationLabel(ovc, hardware)
+}
+
+// Labels are read again and retried when other process updates them at the same time
+func (o *Oneview) releaseReservationLabel(ovc *ov.OVClient, hardware ov.ServerHardware) error {
+	myLabel := reservationLabelPrefix + o.ServerProfileName
+	for retry := 0; ; retry++ {
+		labels, err := getResourceLabelSet(ovc, hardware.URI.String())
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if !hasLabel(labels.Labels, myLabel) {
+			return nil
+		}
+
+		log.Infof("Remove reservation label %s from %s", myLabel, hardware.Name)
+		labels.Labels = removeLabel(labels.Labels, myLabel)
+		err = putResourceLabels(ovc, labels)
+		if isLabelConflict(err) && retry < releaseLabelRetry {
+			log.Debugf("Labels of %s are updated by other process. Retry", hardware.Name)
+			continue
+		}
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		return nil
+	}
+}
+
+// Labels are updated by other process after they were read
+func isLabelConflict(err error) bool {
+	return isStatus(err, http.StatusPreconditionFailed) || isStatus(err, http.StatusConflict)
+}
+
+// Reservation is left by crashed process or other store when server hardware has no server profile
+// and server profile of owner does not exist.
+// Owner which has not created server profile yet may lose reservation, but HPE OneView rejects
+// second server profile on same server hardware.
+func isStaleReservation(ovc *ov.OVClient, hardware ov.ServerHardware, owner string) (bool, error) {
+	if !hardware.ServerProfileURI.IsNil() {
+		return false, nil
+	}
+	profile, err := ovc.GetProfileByName(owner)
+	if err != nil {
+		log.Error(Wrap(err))
+		return false, err
+	}
+	return profile.Name == "", nil
+}
+
+// Update all labels of OneView resource.
+// eTag of labels is sent in If-Match, so update fails when other process has updated them.
+func putResourceLabels(ovc *ov.OVClient, labels *ovResourceLabels) error {
+	if labels.Labels == nil {
+		labels.Labels = []ovLabel{}
+	}
+	ovc.RefreshLogin()
+	headers := map[string]string{}
+	if labels.ETag != "" {
+		headers["If-Match"] = labels.ETag
+	}
+
+	var err error
+	if labels.Uri != "" {
+		err = ovStatusCall(ovc, rest.PUT, labels.Uri, headers, labels, nil)
+	} else {
+		err = ovStatusCall(ovc, rest.POST, "/rest/labels/resources", headers, labels, nil)
+	}
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+func removeLabel(labels []ovLabel, name string) []ovLabel {
+	var remains []ovLabel
+	for _, label := range labels {
+		if label.Name != name {
+			remains = append(remains, label)
+		}
+	}
+	return remains
+}
+
+func hasLabel(labels []ovLabel, name string) bool {
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Return reservation owner other than myself
+func reservationOwner(labels []ovLabel, myLabel string) string {
+	for _, label := range labels {
+		if strings.HasPrefix(label.Name, reservationLabelPrefix) && label.Name != myLabel {
+			return strings.TrimPrefix(label.Name, reservationLabelPrefix)
+		}
+	}
+	return ""
+}
+
+// Reserve server hardware with lock file and OneView label.
+// Server hardware is selected from pool in pool mode.
+func (d *Driver) reserveServerHardware() error {
+	o := d.HpeConfig.Oneview
+	if o.ServerHardwarePool.IsEnabled() && o.ServerHardwareName == "" {
+		return o.SelectServerHardware(d.reserve)
+	}
+	if err := d.reserve(o.ServerHardwareName); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+func (d *Driver) reserve(hardwareName string) error {
+	lock := NewHardwareLock(d.StorePath, hardwareName, d.GetMachineName())
+	if err := lock.Acquire(); err != nil {
+		return err
+	}
+	if err := d.HpeConfig.Oneview.ReserveServerHardware(hardwareName); err != nil {
+		lock.Release()
+		return err
+	}
+	log.Infof("Server hardware %s is reserved for %s", hardwareName, d.GetMachineName())
+	return nil
+}
+
+// Release lock file and OneView label of reserved server hardware
+func (d *Driver) releaseServerHardware() error {
+	hardwareName := d.HpeConfig.Oneview.ServerHardwareName
+	if hardwareName == "" {
+		return nil
+	}
+	errLabel := d.HpeConfig.Oneview.ReleaseServerHardware(hardwareName)
+	if errLabel != nil {
+		log.Warn(Wrap(errLabel))
+	}
+	lock := NewHardwareLock(d.StorePath, hardwareName, d.GetMachineName())
+	if err := lock.Release(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return errLabel
+}
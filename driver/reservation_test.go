@@ -0,0 +1,248 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestReservationHardwareLock(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "ov-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(storePath)
+	for _, machine := range []string{"bay4", "bay5"} {
+		if err := os.MkdirAll(filepath.Join(storePath, "machines", machine), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hardwareName := "SGH652SV73, bay 5"
+	lock := NewHardwareLock(storePath, hardwareName, "bay5")
+	if err := lock.Acquire(); err != nil {
+		t.Fatal(err)
+	}
+	// Owner can acquire again when resuming
+	if err := lock.Acquire(); err != nil {
+		t.Fatal(err)
+	}
+
+	other := NewHardwareLock(storePath, hardwareName, "bay4")
+	if err := other.Acquire(); err == nil {
+		t.Fatal("Same server hardware is reserved twice")
+	}
+	// Not owner can not release
+	if err := other.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(lock.Path); err != nil {
+		t.Fatal("Lock file is released by other owner")
+	}
+
+	// Lock of removed machine is stale
+	if err := os.RemoveAll(filepath.Join(storePath, "machines", "bay5")); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Acquire(); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(other.Path); !os.IsNotExist(err) {
+		t.Fatal("Lock file is not released")
+	}
+}
+
+func TestReservationStaleLockParallel(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "ov-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(storePath)
+
+	// Lock file of removed machine is replaced by only one process
+	hardwareName := "SGH652SV73, bay 5"
+	stale := NewHardwareLock(storePath, hardwareName, "removed")
+	if err := stale.Acquire(); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var owners []string
+	for i := 0; i < 10; i++ {
+		owner := fmt.Sprintf("node%d", i)
+		if err := os.MkdirAll(filepath.Join(storePath, "machines", owner), 0700); err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(owner string) {
+			defer wg.Done()
+			if err := NewHardwareLock(storePath, hardwareName, owner).Acquire(); err == nil {
+				mutex.Lock()
+				defer mutex.Unlock()
+				owners = append(owners, owner)
+			}
+		}(owner)
+	}
+	wg.Wait()
+	if len(owners) != 1 {
+		t.Fatalf("Stale lock should be replaced by one owner: %v", owners)
+	}
+	if owner, _ := stale.currentOwner(); owner != owners[0] {
+		t.Errorf("Lock file is owned by %s, not %s", owner, owners[0])
+	}
+}
+
+func TestReservationOwner(t *testing.T) {
+	myLabel := reservationLabelPrefix + "ov-docker-machine-bay5"
+	labels := []ovLabel{{Name: "rancher"}, {Name: myLabel}}
+	if owner := reservationOwner(labels, myLabel); owner != "" {
+		t.Fatalf("Own reservation is detected as other owner: %s", owner)
+	}
+	labels = append(labels, ovLabel{Name: reservationLabelPrefix + "ov-docker-machine-bay4"})
+	if owner := reservationOwner(labels, myLabel); owner != "ov-docker-machine-bay4" {
+		t.Fatalf("Other reservation is not detected: %s", owner)
+	}
+}
+
+// Fake HPE OneView which rejects update of labels with old eTag
+type testLabelServer struct {
+	sync.Mutex
+	labels    []ovLabel
+	version   int
+	profiles  []string // Names of existing server profiles
+	beforePut func()   // Called once before labels are updated
+	server    *httptest.Server
+}
+
+func createTestLabelServer() *testLabelServer {
+	s := &testLabelServer{}
+	labelUri := "/rest/labels/resources/rest/server-hardware/bay1"
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == labelUri && r.Method == http.MethodPut && s.beforePut != nil {
+			beforePut := s.beforePut
+			s.beforePut = nil
+			beforePut()
+		}
+		s.Lock()
+		defer s.Unlock()
+		switch {
+		case r.URL.Path == "/rest/version":
+			fmt.Fprint(w, `{"currentVersion":2400,"minimumVersion":120}`)
+		case r.URL.Path == "/rest/login-sessions":
+			fmt.Fprint(w, `{"sessionID":"label-session"}`)
+		case r.URL.Path == "/rest/sessions/idle-timeout":
+			fmt.Fprint(w, `{"idleTimeout":86400000}`)
+		case r.URL.Path == "/rest/server-hardware":
+			fmt.Fprint(w, `{"total":1,"count":1,"members":[{"name":"bay1","uri":"/rest/server-hardware/bay1"}]}`)
+		case r.URL.Path == "/rest/server-profiles":
+			for _, name := range s.profiles {
+				if r.URL.Query().Get("filter") == fmt.Sprintf("name matches '%s'", name) {
+					fmt.Fprintf(w, `{"total":1,"count":1,"members":[{"name":"%s"}]}`, name)
+					return
+				}
+			}
+			fmt.Fprint(w, `{"total":0,"count":0,"members":[]}`)
+		case r.URL.Path == labelUri && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(ovResourceLabels{
+				ResourceUri: "/rest/server-hardware/bay1",
+				Labels:      s.labels,
+				Uri:         labelUri,
+				ETag:        fmt.Sprint(s.version),
+			})
+		case r.URL.Path == labelUri && r.Method == http.MethodPut:
+			if r.Header.Get("If-Match") != fmt.Sprint(s.version) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			var labels ovResourceLabels
+			json.NewDecoder(r.Body).Decode(&labels)
+			s.labels = labels.Labels
+			s.version++
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func createTestLabelOneview(endpoint, profileName string) *Oneview {
+	return &Oneview{
+		Endpoint:          endpoint,
+		ApiVersion:        1200,
+		Username:          "rancher",
+		Password:          "password",
+		ServerProfileName: profileName,
+	}
+}
+
+func TestReservationLabelConflict(t *testing.T) {
+	s := createTestLabelServer()
+	defer s.server.Close()
+	mine := createTestLabelOneview(s.server.URL, "ov-docker-machine-bay5")
+	other := createTestLabelOneview(s.server.URL, "ov-docker-machine-bay4")
+	s.profiles = []string{"ov-docker-machine-bay4"}
+
+	// Other process puts label after this process has read labels
+	s.beforePut = func() {
+		if err := other.ReserveServerHardware("bay1"); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := mine.ReserveServerHardware("bay1"); err == nil {
+		t.Fatal("Reservation should fail when labels are updated at the same time")
+	}
+	expected := []ovLabel{{Name: reservationLabelPrefix + "ov-docker-machine-bay4"}}
+	if fmt.Sprint(s.labels) != fmt.Sprint(expected) {
+		t.Fatalf("Unexpected labels: %v", s.labels)
+	}
+
+	// Release is retried when labels are updated at the same time
+	s.beforePut = func() {
+		s.Lock()
+		s.labels = append(s.labels, ovLabel{Name: "rancher"})
+		s.version++
+		s.Unlock()
+	}
+	if err := other.ReleaseServerHardware("bay1"); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(s.labels) != fmt.Sprint([]ovLabel{{Name: "rancher"}}) {
+		t.Errorf("Unexpected labels: %v", s.labels)
+	}
+	if err := mine.ReserveServerHardware("bay1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReservationStaleLabel(t *testing.T) {
+	s := createTestLabelServer()
+	defer s.server.Close()
+	mine := createTestLabelOneview(s.server.URL, "ov-docker-machine-bay5")
+	s.labels = []ovLabel{{Name: "rancher"}, {Name: reservationLabelPrefix + "ov-docker-machine-bay4"}}
+
+	// Owner has server profile
+	s.profiles = []string{"ov-docker-machine-bay4"}
+	if err := mine.ReserveServerHardware("bay1"); err == nil {
+		t.Fatal("Reservation of existing owner should not be reclaimed")
+	}
+
+	// Owner crashed before creating server profile
+	s.profiles = nil
+	if err := mine.ReserveServerHardware("bay1"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []ovLabel{{Name: "rancher"}, {Name: reservationLabelPrefix + "ov-docker-machine-bay5"}}
+	if fmt.Sprint(s.labels) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected labels: %v", s.labels)
+	}
+}
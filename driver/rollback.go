@@ -0,0 +1,47 @@
+package driver
+
+import (
+	"fmt"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+// Undo actions of completed Create stages
+type rollback struct {
+	actions []rollbackAction
+}
+
+type rollbackAction struct {
+	name string
+	undo func() error
+}
+
+// Register undo action of completed stage
+func (r *rollback) push(name string, undo func() error) {
+	r.actions = append(r.actions, rollbackAction{
+		name: name,
+		undo: undo,
+	})
+}
+
+// Execute undo actions in reverse order.
+// All actions are tried even if one of them fails.
+func (r *rollback) run() error {
+	var failed []string
+	for i := len(r.actions) - 1; i >= 0; i-- {
+		action := r.actions[i]
+		log.Infof("Rollback: %s", action.name)
+		if err := action.undo(); err != nil {
+			log.Error(Wrap(err))
+			failed = append(failed, fmt.Sprintf("%s: %v", action.name, err))
+		}
+	}
+	r.actions = nil
+
+	if len(failed) > 0 {
+		err := fmt.Errorf("Rollback failed. Clean up manually: %v", failed)
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
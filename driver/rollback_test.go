@@ -0,0 +1,35 @@
+package driver
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRollbackRun(t *testing.T) {
+	var executed []string
+	rb := &rollback{}
+	for _, name := range []string{"delete profile", "eject media", "power off"} {
+		name := name
+		rb.push(name, func() error {
+			executed = append(executed, name)
+			if name == "eject media" {
+				return fmt.Errorf("eject failed")
+			}
+			return nil
+		})
+	}
+
+	if err := rb.run(); err == nil {
+		t.Fatal("Rollback error is not reported")
+	}
+	expected := []string{"power off", "eject media", "delete profile"}
+	if !reflect.DeepEqual(executed, expected) {
+		t.Fatalf("Rollback order is %v, expected %v", executed, expected)
+	}
+
+	// Actions are cleared after run
+	if err := rb.run(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -11,14 +11,39 @@ import (
 )
 
 type Server struct {
-	Address       string `yaml:"address"`
-	KsBaseUrl     string `yaml:"kickstart-base-url"`
-	OsUrl         string `yaml:"os-url"`
-	RootPassword  string `default:"password" yaml:"root-password"`
-	KsUrl         string
-	SshPublicKey  string
-	SshPrivateKey string
-	Hostname      string
+	Address          string   `yaml:"address"`
+	KsBaseUrl        string   `yaml:"kickstart-base-url"`
+	OsUrl            string   `yaml:"os-url"`
+	RootPassword     string   `default:"password" yaml:"root-password"`
+	PasswordLogin    bool     `yaml:"password-login,omitempty"` // Copy public key with root password login after installation
+	ShutdownTimeout  int      `yaml:"shutdown-timeout,omitempty"`
+	IpamFile         string   `yaml:"ipam-file,omitempty"` // Local IPAM file to lease IP address from
+	Netmask          string   `yaml:"netmask,omitempty"`
+	Gateway          string   `yaml:"gateway,omitempty"`
+	DnsServers       []string `yaml:"dns-servers,omitempty"`
+	Domain           string   `yaml:"domain,omitempty"`
+	KsOutputDir      string   `yaml:"kickstart-output-dir,omitempty"` // Directory on web server to write generated kickstart image
+	KsTemplate       string   `yaml:"kickstart-template,omitempty"`   // Go text/template of kickstart file
+	KsLabel          string   `yaml:"kickstart-label,omitempty"`      // Volume label of kickstart image
+	KsFormat         string   `yaml:"kickstart-format,omitempty"`     // iso or fat
+	HttpAddress      string   `yaml:"http-address,omitempty"`         // IP:port of embedded HTTP server
+	InstallMethod    string   `yaml:"install-method,omitempty"`       // kickstart, autoinstall, autoyast or ignition
+	OsImage          string   `yaml:"os-image,omitempty"`             // Local OS image served by embedded HTTP server
+	BootTarget       string   `yaml:"boot-target,omitempty"`          // One-time boot target: Cd, UefiTarget, UefiBootNext or UefiHttp
+	InstallTransport string   `yaml:"install-transport,omitempty"`    // virtual-media or http-boot
+	HttpBootUrl      string   `yaml:"http-boot-url,omitempty"`        // URL of UEFI HTTP boot image. Generated from local OS image if empty.
+	SshHardening     bool     `yaml:"ssh-hardening,omitempty"`        // Harden sshd and create sudo user after installation
+	SshUser          string   `yaml:"ssh-user,omitempty"`             // Sudo user created by hardening
+	SshPort          int      `yaml:"ssh-port,omitempty"`             // Port of sshd set by hardening
+	KsUrl            string
+	SshPublicKey     string
+	SshPrivateKey    string
+	SshKeyPath       string `yaml:"-"` // Private key file used for key login
+	Hostname         string
+	Allocation       *IpAllocation `yaml:"-"` // Allocated IP address and network settings
+	SshHardened      bool          `yaml:"-"` // Hardening is applied to installed OS
+
+	httpListenAddress string // Address which embedded HTTP server listens on
 }
 
 const (
@@ -26,26 +51,100 @@ const (
 	defaultWebTimeout      = 1
 	defaultInstallTimeout  = 1800 //sec
 	defaultInstallInterval = 30
+	defaultShutdownTimeout = 120 //sec
+	defaultRootPassword    = "password"
 )
 
+// Public key is registered by generated install config, so driver logs in with key only
+func (s *Server) isKeyLogin() bool {
+	return s.SshHardened || s.installMethod().keyOnly || (s.IsKickstartGenerated() && !s.PasswordLogin)
+}
+
+// Root password is locked when it is not specified and key login is used
+func (s *Server) rootPassword() string {
+	if s.RootPassword == "" && !s.isKeyLogin() {
+		return defaultRootPassword
+	}
+	return s.RootPassword
+}
+
 func (s *Server) Validate() error {
-	// Check os image iso URL
-	client := &http.Client{
-		Timeout: defaultWebTimeout * time.Second,
+	if err := s.validateInstallMethod(); err != nil {
+		log.Error(Wrap(err))
+		return err
 	}
-	imageUrl := s.OsUrl
-	respOsImage, err := client.Get(imageUrl)
-	if err != nil {
+	if err := s.validateSshHardening(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if _, err := parseBootTarget(s.bootTarget()); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := s.validateInstallTransport(); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if s.OsImage != "" && !s.IsHttpServerEnabled() {
+		err := fmt.Errorf("HTTP address is required to serve OS image %s", s.OsImage)
 		log.Error(Wrap(err))
 		return err
 	}
-	if respOsImage.StatusCode >= 400 {
-		err := fmt.Errorf("Could not access %s: %d", imageUrl, respOsImage.StatusCode)
+	if s.IsHttpServerEnabled() {
+		if err := s.validateHttpServer(); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	// Check os image iso URL. Local OS image is checked above.
+	// UEFI HTTP boot does not use OS image URL.
+	imageUrl, remote := s.OsUrl, s.OsImage == ""
+	if s.IsHttpBoot() {
+		imageUrl, remote = s.HttpBootUrl, s.HttpBootUrl != ""
+	}
+	if remote {
+		client := &http.Client{
+			Timeout: defaultWebTimeout * time.Second,
+		}
+		respOsImage, err := client.Get(imageUrl)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		if respOsImage.StatusCode >= 400 {
+			err := fmt.Errorf("Could not access %s: %d", imageUrl, respOsImage.StatusCode)
+			log.Error(Wrap(err))
+			return err
+		}
+	}
+
+	if s.IsKickstartGenerated() {
+		if err := s.validateKickstart(); err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+		return nil
+	}
+
+	// Kickstart image is named after IP address
+	if s.Address == "" {
+		log.Info("Kickstart image will be checked after IP address is allocated")
+		return nil
+	}
+	if err := s.CheckKickstart(); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
 
-	// Check ks image iso URL
+	return nil
+}
+
+// Check ks image iso URL
+func (s *Server) CheckKickstart() error {
+	client := &http.Client{
+		Timeout: defaultWebTimeout * time.Second,
+	}
 	ksUrl := s.KsUrl
 	respKsImage, err := client.Get(ksUrl)
 	if err != nil {
@@ -53,25 +152,39 @@ func (s *Server) Validate() error {
 		return err
 	}
 	if respKsImage.StatusCode >= 400 {
-		err := fmt.Errorf("Could not access %v: %d", s.OsUrl, respKsImage.StatusCode)
+		err := fmt.Errorf("Could not access %v: %d", ksUrl, respKsImage.StatusCode)
 		log.Error(Wrap(err))
 		return err
 	}
-
 	return nil
 }
 
+// Use allocated IP address for new server
+func (s *Server) SetAllocation(allocation *IpAllocation) {
+	s.Allocation = allocation
+	s.Address = allocation.Address
+	s.updateKsUrl()
+}
+
 func (s *Server) RemoteShell(shell string, port int) error {
 	address := s.Address
+	auth := &ssh.Auth{
+		Passwords: []string{
+			s.rootPassword(),
+		},
+	}
+	if s.isKeyLogin() {
+		auth = &ssh.Auth{
+			Keys: []string{
+				s.SshKeyPath,
+			},
+		}
+	}
 	sshClient, err := ssh.NewNativeClient(
-		"root",
+		s.sshUser(),
 		address,
 		port,
-		&ssh.Auth{
-			Passwords: []string{
-				s.RootPassword,
-			},
-		},
+		auth,
 	)
 	log.Debugf("Initialize ssh client: %#v", sshClient)
 	if err != nil {
@@ -103,7 +216,7 @@ func (s *Server) WaitOsInstallation() error {
 func (s *Server) sshAvailableFunc() func() bool {
 	return func() bool {
 		log.Infof("Waiting for SSH to be available...")
-		if err := s.RemoteShell("echo hello", 22); err != nil {
+		if err := s.RemoteShell("echo hello", s.sshPort()); err != nil {
 			return false
 		}
 		return true
@@ -119,13 +232,39 @@ func (s *Server) CopySshPubKey() error {
 		log.Error(Wrap(err))
 		return err
 	}
+	// Key is registered by install config and used for login
+	if s.isKeyLogin() {
+		log.Infof("Public key is registered for %s by %s", s.sshUser(), s.installMethodName())
+		return s.CheckSshPubKey()
+	}
 	shell := fmt.Sprintf(`
 	mkdir -p /root/.ssh 
 	echo "%s docker-machine-ov" >> /root/.ssh/authorized_keys
 	chmod 0600 /root/.ssh/authorized_keys
 	`, pubkey)
 	log.Debugf("Shell: %s", shell)
-	if err := s.RemoteShell(shell, 22); err != nil {
+	if err := s.RemoteShell(shell, s.sshPort()); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Check public key is registered on new server
+func (s *Server) CheckSshPubKey() error {
+	pubkey := s.SshPublicKey
+	if pubkey == "" {
+		err := fmt.Errorf("Public key is not generated")
+		log.Error(Wrap(err))
+		return err
+	}
+	shell := fmt.Sprintf(`grep -q "%s" /root/.ssh/authorized_keys`, pubkey)
+	// Login itself is checked because authorized_keys path depends on OS
+	if s.isKeyLogin() {
+		shell = "true"
+	}
+	log.Debugf("Shell: %s", shell)
+	if err := s.RemoteShell(shell, s.sshPort()); err != nil {
 		log.Error(Wrap(err))
 		return err
 	}
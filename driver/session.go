@@ -0,0 +1,241 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	sessionDirName       = "ov-sessions"
+	defaultSessionExpiry = 3600 //sec
+)
+
+// HPE OneView login session shared between driver operations
+type OneviewSession struct {
+	Endpoint  string    `json:"endpoint"`
+	Domain    string    `json:"domain"`
+	Username  string    `json:"username"`
+	SessionId string    `json:"sessionId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Session cache on memory and under docker-machine store
+type SessionCache struct {
+	Dir    string
+	Expiry time.Duration
+}
+
+// Sessions of this process
+var sessionMemory = struct {
+	sync.Mutex
+	sessions map[string]*OneviewSession
+}{
+	sessions: map[string]*OneviewSession{},
+}
+
+func NewSessionCache(dir string) *SessionCache {
+	return &SessionCache{
+		Dir:    dir,
+		Expiry: defaultSessionExpiry * time.Second,
+	}
+}
+
+func sessionKey(endpoint, domain, username string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(endpoint+"\n"+domain+"\n"+username)))
+}
+
+func (c *SessionCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Get valid session. Return nil if session is not cached or expired.
+func (c *SessionCache) Load(endpoint, domain, username string) *OneviewSession {
+	key := sessionKey(endpoint, domain, username)
+
+	sessionMemory.Lock()
+	session := sessionMemory.sessions[key]
+	sessionMemory.Unlock()
+
+	if session == nil && c.Dir != "" {
+		bytes, err := ioutil.ReadFile(c.path(key))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Warn(Wrap(err))
+			}
+			return nil
+		}
+		session = &OneviewSession{}
+		if err := json.Unmarshal(bytes, session); err != nil {
+			log.Warn(Wrap(err))
+			return nil
+		}
+	}
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return nil
+	}
+	return session
+}
+
+// Store new session on memory and disk
+func (c *SessionCache) Save(session *OneviewSession) error {
+	session.ExpiresAt = time.Now().Add(c.Expiry)
+	key := sessionKey(session.Endpoint, session.Domain, session.Username)
+
+	sessionMemory.Lock()
+	sessionMemory.sessions[key] = session
+	sessionMemory.Unlock()
+
+	if c.Dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	bytes, err := json.Marshal(session)
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	// Other driver processes may read this file at the same time
+	tmpPath := fmt.Sprintf("%s.%d.tmp", c.path(key), os.Getpid())
+	if err := ioutil.WriteFile(tmpPath, bytes, 0600); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	if err := os.Rename(tmpPath, c.path(key)); err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+// Forget session
+func (c *SessionCache) Delete(endpoint, domain, username string) error {
+	key := sessionKey(endpoint, domain, username)
+
+	sessionMemory.Lock()
+	delete(sessionMemory.sessions, key)
+	sessionMemory.Unlock()
+
+	if c.Dir == "" {
+		return nil
+	}
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		log.Error(Wrap(err))
+		return err
+	}
+	return nil
+}
+
+func isUnauthorized(err error) bool {
+	return isStatus(err, http.StatusUnauthorized)
+}
+
+func (o *Oneview) sessionCache() *SessionCache {
+	return NewSessionCache(o.SessionCacheDir)
+}
+
+// Use cached session if appliance accepts it. Otherwise login again.
+func (o *Oneview) refreshSession(ovc *ov.OVClient) error {
+	cache := o.sessionCache()
+	if ovc.APIKey == "" || ovc.APIKey == "none" {
+		if session := cache.Load(o.Endpoint, o.Domain, o.Username); session != nil {
+			ovc.APIKey = session.SessionId
+		}
+	}
+
+	if ovc.APIKey != "" && ovc.APIKey != "none" {
+		err := ovStatusCall(ovc, rest.GET, "/rest/sessions/idle-timeout", map[string]string{"Session-ID": ovc.APIKey}, nil, nil)
+		if err == nil {
+			return nil
+		}
+		if !isUnauthorized(err) {
+			log.Error(Wrap(err))
+			return err
+		}
+		log.Debugf("HPE OneView session is expired. Login again")
+		cache.Delete(o.Endpoint, o.Domain, o.Username)
+	}
+
+	log.Debugf("Login to HPE OneView %s as %s", o.Endpoint, o.Username)
+	session, err := ovc.SessionLogin()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	ovc.APIKey = session.ID
+	if err := cache.Save(&OneviewSession{
+		Endpoint:  o.Endpoint,
+		Domain:    o.Domain,
+		Username:  o.Username,
+		SessionId: session.ID,
+	}); err != nil {
+		log.Warn(Wrap(err))
+	}
+	return nil
+}
+
+// Logout from HPE OneView and delete cached session
+func (o *Oneview) Logout() error {
+	cache := o.sessionCache()
+	session := cache.Load(o.Endpoint, o.Domain, o.Username)
+	if session == nil {
+		return nil
+	}
+
+	ovc, err := o.newOVClient()
+	if err != nil {
+		log.Error(Wrap(err))
+		return err
+	}
+	ovc.APIKey = session.SessionId
+	log.Debugf("Logout from HPE OneView %s", o.Endpoint)
+	err = ovStatusCall(ovc, rest.DELETE, "/rest/login-sessions", nil, nil, nil)
+	if err != nil && !isUnauthorized(err) {
+		log.Warn(Wrap(err))
+	}
+	return cache.Delete(o.Endpoint, o.Domain, o.Username)
+}
+
+// Cached session is shared with other machines in docker-machine store
+// which log in to same HPE OneView as same user.
+// Machine without saved config may be being created, so it is counted as well.
+func (d *Driver) isSessionShared() bool {
+	o := d.HpeConfig.Oneview
+	dirs, err := ioutil.ReadDir(filepath.Join(d.StorePath, "machines"))
+	if err != nil {
+		log.Debug(Wrap(err))
+		return false
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() || dir.Name() == d.GetMachineName() {
+			continue
+		}
+		_, other, err := loadMachine(d.StorePath, dir.Name())
+		if os.IsNotExist(err) {
+			log.Debugf("Machine %s does not have saved config yet", dir.Name())
+			return true
+		}
+		if err != nil {
+			continue
+		}
+		if sessionKey(other.HpeConfig.Oneview.Endpoint, other.HpeConfig.Oneview.Domain, other.HpeConfig.Oneview.Username) ==
+			sessionKey(o.Endpoint, o.Domain, o.Username) {
+			log.Debugf("HPE OneView session is used by machine %s", dir.Name())
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package driver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// Fake HPE OneView which counts login requests
+type testSessionServer struct {
+	sync.Mutex
+	logins  int
+	valid   map[string]bool
+	server  *httptest.Server
+	logouts int
+}
+
+func createTestSessionServer() *testSessionServer {
+	s := &testSessionServer{valid: map[string]bool{}}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		switch r.URL.Path {
+		case "/rest/version":
+			fmt.Fprint(w, `{"currentVersion":2400,"minimumVersion":120}`)
+		case "/rest/login-sessions":
+			if r.Method == http.MethodDelete {
+				s.logouts++
+				delete(s.valid, r.Header.Get("auth"))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			s.logins++
+			id := fmt.Sprintf("session-%d", s.logins)
+			s.valid[id] = true
+			fmt.Fprintf(w, `{"sessionID":"%s"}`, id)
+		case "/rest/sessions/idle-timeout":
+			if !s.valid[r.Header.Get("Session-ID")] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"idleTimeout":86400000}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func TestSessionReuse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ov-sessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := createTestSessionServer()
+	defer s.server.Close()
+
+	o := &Oneview{
+		Endpoint:        s.server.URL,
+		ApiVersion:      1200,
+		Username:        "rancher",
+		Password:        "password",
+		SessionCacheDir: dir,
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := o.NewClient(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.logins != 1 {
+		t.Fatalf("Login is executed %d times", s.logins)
+	}
+
+	// Session is expired on appliance
+	s.Lock()
+	s.valid = map[string]bool{}
+	s.Unlock()
+	ovc, err := o.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.logins != 2 || ovc.APIKey != "session-2" {
+		t.Fatalf("Login is not executed again: %d %s", s.logins, ovc.APIKey)
+	}
+
+	// Session on disk is used by other process
+	sessionMemory.Lock()
+	sessionMemory.sessions = map[string]*OneviewSession{}
+	sessionMemory.Unlock()
+	if _, err := o.NewClient(); err != nil {
+		t.Fatal(err)
+	}
+	if s.logins != 2 {
+		t.Fatalf("Session on disk is not used: %d", s.logins)
+	}
+
+	if err := o.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	if s.logouts != 1 {
+		t.Fatal("Logout is not executed")
+	}
+	if session := o.sessionCache().Load(o.Endpoint, o.Domain, o.Username); session != nil {
+		t.Fatalf("Session is not deleted: %#v", session)
+	}
+}
+
+func TestSessionShared(t *testing.T) {
+	storePath, err := ioutil.TempDir("", "ov-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(storePath)
+
+	d := NewDriver("node1", storePath)
+	d.HpeConfig = &HpeConfig{
+		Oneview: &Oneview{Endpoint: "https://192.168.2.6", Username: "rancher"},
+		Server:  &Server{},
+	}
+	ovMachine := func(username string) map[string]interface{} {
+		return map[string]interface{}{
+			"DriverName": driverName,
+			"Driver": map[string]interface{}{
+				"Oneview": map[string]interface{}{"Endpoint": "https://192.168.2.6", "Username": username},
+				"Server":  map[string]interface{}{},
+			},
+		}
+	}
+	writeTestMachineConfig(t, storePath, "node1", ovMachine("rancher"))
+	writeTestMachineConfig(t, storePath, "vm1", map[string]interface{}{
+		"DriverName": "virtualbox",
+		"Driver":     map[string]interface{}{},
+	})
+	writeTestMachineConfig(t, storePath, "node2", ovMachine("admin"))
+	if d.isSessionShared() {
+		t.Fatal("Session should not be shared with machines of other driver or user")
+	}
+
+	writeTestMachineConfig(t, storePath, "node3", ovMachine("rancher"))
+	if !d.isSessionShared() {
+		t.Fatal("Session should be shared with machine of same user")
+	}
+
+	// Machine being created does not have saved config yet
+	os.RemoveAll(filepath.Join(storePath, "machines", "node3"))
+	if err := os.MkdirAll(filepath.Join(storePath, "machines", "node4"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if !d.isSessionShared() {
+		t.Fatal("Session should be shared with machine being created")
+	}
+}
+
+func TestSessionUnauthorized(t *testing.T) {
+	if isUnauthorized(fmt.Errorf("Error in response: server-hardware/401 Response Status: 500")) {
+		t.Error("Status in message should not be checked")
+	}
+	if !isUnauthorized(&ovStatusError{StatusCode: http.StatusUnauthorized}) {
+		t.Error("401 should be unauthorized")
+	}
+
+	// Appliance error other than 401 is not treated as expired session
+	s := createTestSessionServer()
+	defer s.server.Close()
+	o := &Oneview{
+		Endpoint:   s.server.URL,
+		ApiVersion: 1200,
+		Username:   "rancher",
+		Password:   "password",
+	}
+	if _, err := o.NewClient(); err != nil {
+		t.Fatal(err)
+	}
+	handler := s.server.Config.Handler
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/sessions/idle-timeout" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+	if _, err := o.NewClient(); !isStatus(err, http.StatusNotFound) {
+		t.Errorf("404 should be returned as error: %v", err)
+	}
+	if s.logins != 1 {
+		t.Errorf("Login should not be executed again: %d", s.logins)
+	}
+}
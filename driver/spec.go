@@ -11,9 +11,10 @@ import (
 )
 
 type HpeConfig struct {
-	Oneview *Oneview `yaml:"oneview"`
-	Server  *Server  `yaml:"server"`
-	Yaml    *Yaml
+	Oneview       *Oneview `yaml:"oneview"`
+	Server        *Server  `yaml:"server"`
+	KeepOnFailure bool     `yaml:"keep-on-failure"`
+	Yaml          *Yaml
 }
 
 type Yaml struct {
@@ -79,11 +80,11 @@ var mcnFlags = []mcnflag.Flag{
 		Usage:  "HPE OneView endpoint URL.",
 		Value:  "",
 	},
-	mcnflag.IntFlag{
+	mcnflag.StringFlag{
 		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_API_VERSION",
 		Name:   driverName + "-oneview-api-version",
-		Usage:  "HPE OneView API version.",
-		Value:  1800,
+		Usage:  "HPE OneView API version. \"auto\" selects highest version which both HPE OneView and this driver support.",
+		Value:  apiVersionAuto,
 	},
 	mcnflag.StringFlag{
 		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_USER",
@@ -113,19 +114,131 @@ var mcnFlags = []mcnflag.Flag{
 		Name:   driverName + "-oneview-server-hardware",
 		Usage:  "HPE OneView server hardware name. This server will be target server. (EXACTLY same name as OneView displayed. There is a case to need spaces between strings when hardware name is displayed with sapces in OneView.)",
 	},
+	mcnflag.IntFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_TASK_TIMEOUT",
+		Name:   driverName + "-oneview-task-timeout",
+		Usage:  "(Option) Timeout seconds to wait for HPE OneView tasks such as server profile creation.",
+		Value:  defaultTaskTimeout,
+	},
+	mcnflag.IntFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_POWER_TIMEOUT",
+		Name:   driverName + "-oneview-power-timeout",
+		Usage:  "(Option) Timeout seconds to wait for server power state change such as stop, kill and restart.",
+		Value:  defaultPowerTimeout,
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_RESET_TYPE",
+		Name:   driverName + "-oneview-reset-type",
+		Usage:  "(Option) Reset type used by restart. \"warm\" is normal reset and \"cold\" is cold boot which removes power immediately.",
+		Value:  resetTypeWarm,
+	},
+	mcnflag.StringSliceFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_PROFILE_CONNECTION",
+		Name:   driverName + "-oneview-profile-connection",
+		Usage:  "(Option) Override network of connection in server profile template. Format is ID=NETWORK. This can be specified multiple times.",
+		Value:  []string{},
+	},
+	mcnflag.StringSliceFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_PROFILE_LOGICAL_DRIVE",
+		Name:   driverName + "-oneview-profile-logical-drive",
+		Usage:  "(Option) Override RAID level of local logical drive in server profile template. Format is DEVICE_SLOT:DRIVE_NAME=RAID_LEVEL. This can be specified multiple times.",
+		Value:  []string{},
+	},
+	mcnflag.StringSliceFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_PROFILE_BIOS",
+		Name:   driverName + "-oneview-profile-bios",
+		Usage:  "(Option) Override BIOS setting in server profile. Format is SETTING_ID=VALUE. This can be specified multiple times.",
+		Value:  []string{},
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_PROFILE_BOOT_MODE",
+		Name:   driverName + "-oneview-profile-boot-mode",
+		Usage:  "(Option) Override boot mode in server profile. UEFI, UEFIOptimized or BIOS.",
+		Value:  "",
+	},
+	mcnflag.StringSliceFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_PROFILE_BOOT_ORDER",
+		Name:   driverName + "-oneview-profile-boot-order",
+		Usage:  "(Option) Override boot order in server profile. CD, Floppy, USB, HardDisk, PXE or FibreChannelHba. This can be specified multiple times in boot order.",
+		Value:  []string{},
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_IP_POOL_NETWORK",
+		Name:   driverName + "-oneview-ip-pool-network",
+		Usage:  "(Option) Allocate server IP address from HPE OneView IPv4 subnet associated with this ethernet network instead of server address.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_IP_POOL_RANGE",
+		Name:   driverName + "-oneview-ip-pool-range",
+		Usage:  "(Option) Name of IPv4 range to allocate server IP address from. Any enabled range of subnet is used if empty.",
+		Value:  "",
+	},
+	mcnflag.BoolFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_SERVER_HARDWARE_POOL",
+		Name:   driverName + "-oneview-server-hardware-pool",
+		Usage:  "(Option) Select unassigned, healthy and powered off server hardware which fits server profile template instead of fixed server hardware name.",
+	},
+	mcnflag.StringSliceFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_SERVER_HARDWARE_POOL_LABEL",
+		Name:   driverName + "-oneview-server-hardware-pool-label",
+		Usage:  "(Option) HPE OneView label which server hardware in pool should have. This can be specified multiple times.",
+		Value:  []string{},
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_SERVER_HARDWARE_POOL_ENCLOSURE",
+		Name:   driverName + "-oneview-server-hardware-pool-enclosure",
+		Usage:  "(Option) HPE OneView enclosure name which server hardware in pool belongs to.",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_ONEVIEW_SERVER_HARDWARE_POOL_SCOPE",
+		Name:   driverName + "-oneview-server-hardware-pool-scope",
+		Usage:  "(Option) HPE OneView scope name which server hardware in pool belongs to.",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_TLS_CA_CERT",
+		Name:   driverName + "-tls-ca-cert",
+		Usage:  "(Option) CA bundle path to verify HPE OneView and HPE iLO certificates. System CA is used by default.",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_TLS_ONEVIEW_FINGERPRINT",
+		Name:   driverName + "-tls-oneview-fingerprint",
+		Usage:  "(Option) Pinned SHA-256 fingerprint of HPE OneView certificate like AB:CD:...",
+	},
+	mcnflag.BoolFlag{
+		EnvVar: strings.ToUpper(driverName) + "_TLS_TOFU",
+		Name:   driverName + "-tls-tofu",
+		Usage:  "(Option) Trust HPE OneView and HPE iLO certificates on first use. Fingerprints are saved in machine config and checked on next connection.",
+	},
+	mcnflag.BoolFlag{
+		EnvVar: strings.ToUpper(driverName) + "_TLS_INSECURE",
+		Name:   driverName + "-tls-insecure",
+		Usage:  "(Option) Skip HPE OneView and HPE iLO certificate verification. This is not recommended.",
+	},
 	/**************
 	New server setting
 	**************/
 	mcnflag.StringFlag{
 		EnvVar: strings.ToUpper(driverName) + "_SERVER_ADDRESS",
 		Name:   driverName + "-server-address",
-		Usage:  "Target server IP address.",
+		Usage:  "Target server IP address. This is not required when IP address is allocated from HPE OneView IPv4 subnet or IPAM file.",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_IPAM_FILE",
+		Name:   driverName + "-server-ipam-file",
+		Usage:  "(Option) Local IPAM yaml file to lease server IP address from instead of server address.",
+		Value:  "",
 	},
 	mcnflag.StringFlag{
 		EnvVar: strings.ToUpper(driverName) + "_SERVER_ROOT_PASSWORD",
 		Name:   driverName + "-server-root-password",
-		Usage:  "Target server root user password.",
-		Value:  "password",
+		Usage:  "Target server root user password. If empty, \"password\" is used for password login and root password is locked when public key is registered by generated kickstart.",
+		Value:  "",
+	},
+	mcnflag.BoolFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_PASSWORD_LOGIN",
+		Name:   driverName + "-server-password-login",
+		Usage:  "(Option) Log in with root password and copy public key after installation even if kickstart is generated. This is legacy behavior.",
 	},
 	mcnflag.StringFlag{
 		EnvVar: strings.ToUpper(driverName) + "_SERVER_KICKSTART_BASE_URL",
@@ -137,9 +250,121 @@ var mcnFlags = []mcnflag.Flag{
 		Name:   driverName + "-server-os-url",
 		Usage:  "OS image URL.",
 	},
+	mcnflag.IntFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_SHUTDOWN_TIMEOUT",
+		Name:   driverName + "-server-shutdown-timeout",
+		Usage:  "(Option) Timeout seconds to wait for power off after OS shutdown via ssh. Power button is pressed after this timeout.",
+		Value:  defaultShutdownTimeout,
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_INSTALL_METHOD",
+		Name:   driverName + "-server-install-method",
+		Usage:  "(Option) OS install method. \"kickstart\" is for Red Hat based OS, \"autoinstall\" is for Ubuntu Server with NoCloud seed labeled CIDATA, \"autoyast\" is for SUSE with autoinst.xml labeled OEMDRV and \"ignition\" is for Flatcar or Fedora CoreOS with config.ign labeled ignition.",
+		Value:  installMethodKickstart,
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_BOOT_TARGET",
+		Name:   driverName + "-server-boot-target",
+		Usage:  "(Option) One-time boot target to boot from installation media before power on. \"Cd\", \"UefiTarget\", \"UefiBootNext\" or \"UefiHttp\". UefiTarget and UefiBootNext use UEFI boot option of virtual CD. Default is \"Cd\", or \"UefiHttp\" for UEFI HTTP boot.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_INSTALL_TRANSPORT",
+		Name:   driverName + "-server-install-transport",
+		Usage:  "(Option) How installer is booted. \"virtual-media\" mounts OS image and kickstart image on iLO virtual media. \"http-boot\" sets UEFI HTTP boot URL in BIOS and passes kickstart URL on kernel command line. http-boot supports kickstart only.",
+		Value:  installTransportVirtualMedia,
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_HTTP_BOOT_URL",
+		Name:   driverName + "-server-http-boot-url",
+		Usage:  "(Option) URL of OS image or EFI binary such as iPXE or grub loaded by UEFI HTTP boot. If empty, local OS image is remastered with kickstart URL and served by embedded HTTP server.",
+		Value:  "",
+	},
+	mcnflag.BoolFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_SSH_HARDENING",
+		Name:   driverName + "-server-ssh-hardening",
+		Usage:  "(Option) Disable password and root login of sshd, set random root password and create sudo user for docker-machine after installation.",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_SSH_USER",
+		Name:   driverName + "-server-ssh-user",
+		Usage:  "(Option) Sudo user created by SSH hardening. docker-machine logs in as this user.",
+		Value:  defaultHardenedUser,
+	},
+	mcnflag.IntFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_SSH_PORT",
+		Name:   driverName + "-server-ssh-port",
+		Usage:  "(Option) Port of sshd set by SSH hardening.",
+		Value:  defaultSshPort,
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_HTTP_ADDRESS",
+		Name:   driverName + "-server-http-address",
+		Usage:  "(Option) IP:port of HTTP server embedded in driver. Kickstart image is generated and served on this address instead of external web server. The IP address should be reachable from iLO.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_OS_IMAGE",
+		Name:   driverName + "-server-os-image",
+		Usage:  "(Option) Local OS image file served by embedded HTTP server. This is used instead of OS image URL.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_NETMASK",
+		Name:   driverName + "-server-netmask",
+		Usage:  "(Option) Netmask of server address used in generated kickstart.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_GATEWAY",
+		Name:   driverName + "-server-gateway",
+		Usage:  "(Option) Default gateway used in generated kickstart.",
+		Value:  "",
+	},
+	mcnflag.StringSliceFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_DNS",
+		Name:   driverName + "-server-dns",
+		Usage:  "(Option) DNS server used in generated kickstart. This can be specified multiple times.",
+		Value:  []string{},
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_DOMAIN",
+		Name:   driverName + "-server-domain",
+		Usage:  "(Option) DNS domain of server used in generated kickstart.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_KICKSTART_OUTPUT_DIR",
+		Name:   driverName + "-server-kickstart-output-dir",
+		Usage:  "(Option) Generate kickstart image in this directory instead of preparing it manually. The directory should be published at kickstart base URL.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_KICKSTART_TEMPLATE",
+		Name:   driverName + "-server-kickstart-template",
+		Usage:  "(Option) Go text/template file of kickstart. Built-in template is used if empty.",
+		Value:  "",
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_KICKSTART_LABEL",
+		Name:   driverName + "-server-kickstart-label",
+		Usage:  "(Option) Volume label of generated kickstart image. This should match inst.ks=hd:LABEL=... in OS image.",
+		Value:  defaultKsLabel,
+	},
+	mcnflag.StringFlag{
+		EnvVar: strings.ToUpper(driverName) + "_SERVER_KICKSTART_FORMAT",
+		Name:   driverName + "-server-kickstart-format",
+		Usage:  "(Option) Format of generated kickstart image. \"iso\" is ISO9660 and \"fat\" is FAT floppy image.",
+		Value:  ksFormatIso,
+	},
 	/**************
 	Common
 	**************/
+	mcnflag.BoolFlag{
+		EnvVar: strings.ToUpper(driverName) + "_KEEP_ON_FAILURE",
+		Name:   driverName + "-keep-on-failure",
+		Usage:  "(Option) Keep server profile, virtual media and power state when server creation fails. This is for debugging. Completed stages are rolled back by default.",
+	},
 	mcnflag.BoolFlag{
 		EnvVar: strings.ToUpper(driverName) + "_DEBUG",
 		Name:   driverName + "-debug",
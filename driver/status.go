@@ -0,0 +1,79 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+// Error response of HPE OneView REST API.
+// Library client returns status in message only, so status code is kept here.
+type ovStatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *ovStatusError) Error() string {
+	return fmt.Sprintf("HPE OneView returned %s: %s", e.Status, e.Body)
+}
+
+func isStatus(err error, statusCode int) bool {
+	var statusErr *ovStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == statusCode
+}
+
+// Call HPE OneView REST API through HTTP client of library client.
+// Headers are added to auth headers of library client.
+func ovStatusCall(ovc *ov.OVClient, method rest.Method, uri string, headers map[string]string, body interface{}, result interface{}) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method.String(), strings.TrimSuffix(ovc.Endpoint, "/")+uri, &reqBody)
+	if err != nil {
+		return err
+	}
+	for key, value := range ovc.GetAuthHeaderMap() {
+		req.Header.Set(key, value)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := ovc.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	log.Debugf("%s %s: %s", method, uri, resp.Status)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &ovStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(data),
+		}
+	}
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,162 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	defaultTaskTimeout  = 1800 //sec
+	defaultTaskInterval = 5    //sec
+)
+
+// Follow HPE OneView async task until it finishes
+type TaskWatcher struct {
+	Client   *ov.OVClient
+	Timeout  time.Duration
+	Interval time.Duration
+	Refresh  func(ovc *ov.OVClient) error // Re-login when session is expired
+}
+
+// HPE OneView task finished with error
+type TaskError struct {
+	TaskName           string
+	TaskState          string
+	ErrorCode          string
+	Message            string
+	RecommendedActions []string
+}
+
+func (e *TaskError) Error() string {
+	msg := fmt.Sprintf("HPE OneView task \"%s\" is %s", e.TaskName, e.TaskState)
+	if e.ErrorCode != "" {
+		msg += fmt.Sprintf(": [%s]", e.ErrorCode)
+	}
+	if e.Message != "" {
+		msg += fmt.Sprintf(" %s", e.Message)
+	}
+	if len(e.RecommendedActions) > 0 {
+		msg += fmt.Sprintf(" (Recommended actions: %s)", strings.Join(e.RecommendedActions, " "))
+	}
+	return msg
+}
+
+func NewTaskWatcher(ovc *ov.OVClient, timeoutSec int) *TaskWatcher {
+	if timeoutSec <= 0 {
+		timeoutSec = defaultTaskTimeout
+	}
+	return &TaskWatcher{
+		Client:   ovc,
+		Timeout:  time.Duration(timeoutSec) * time.Second,
+		Interval: defaultTaskInterval * time.Second,
+	}
+}
+
+// Wait task completion and report progress
+func (w *TaskWatcher) Wait(taskUri string) error {
+	if taskUri == "" {
+		err := fmt.Errorf("HPE OneView task URI is empty")
+		log.Error(Wrap(err))
+		return err
+	}
+	log.Debugf("Watch HPE OneView task %s. Timeout is %v", taskUri, w.Timeout)
+
+	deadline := time.Now().Add(w.Timeout)
+	lastProgress := ""
+	for {
+		task, err := w.getTask(taskUri)
+		if err != nil {
+			log.Error(Wrap(err))
+			return err
+		}
+
+		progress := fmt.Sprintf("%d%%, %s", task.PercentComplete, task.TaskStatus)
+		if progress != lastProgress {
+			log.Infof("Task %s: %s", task.Name, progress)
+			lastProgress = progress
+		}
+
+		switch {
+		case ov.T_COMPLETED.Equal(task.TaskState):
+			log.Infof("Task %s completed", task.Name)
+			return nil
+		case ov.T_WARNING.Equal(task.TaskState):
+			for _, taskError := range task.TaskErrors {
+				log.Warnf("Task %s: %s", task.Name, taskError.Message)
+			}
+			return nil
+		case ov.T_ERROR.Equal(task.TaskState),
+			ov.T_KILLED.Equal(task.TaskState),
+			ov.T_TERMINATED.Equal(task.TaskState),
+			ov.T_INERRUPTED.Equal(task.TaskState):
+			err := newTaskError(task)
+			log.Error(Wrap(err))
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			err := fmt.Errorf("HPE OneView task \"%s\" did not complete in %v: %s", task.Name, w.Timeout, progress)
+			log.Error(Wrap(err))
+			return err
+		}
+		time.Sleep(w.Interval)
+	}
+}
+
+func (w *TaskWatcher) getTask(taskUri string) (*ov.Task, error) {
+	ovc := w.Client
+	if w.Refresh != nil {
+		if err := w.Refresh(ovc); err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+	} else {
+		ovc.RefreshLogin()
+	}
+	ovc.SetAuthHeaderOptions(ovc.GetAuthHeaderMap())
+	data, err := ovc.RestAPICall(rest.GET, taskUri, nil)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	var task ov.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	log.Debugf("Task: %s %s %d%% %s", task.Name, task.TaskState, task.PercentComplete, task.TaskStatus)
+	return &task, nil
+}
+
+func newTaskError(task *ov.Task) *TaskError {
+	taskError := &TaskError{
+		TaskName:  task.Name,
+		TaskState: task.TaskState,
+		Message:   task.TaskStatus,
+	}
+	if len(task.TaskErrors) > 0 {
+		// Most detailed error is in nested errors
+		detail := task.TaskErrors[0]
+		for len(detail.NestedErrors) > 0 {
+			detail = detail.NestedErrors[0]
+		}
+		taskError.ErrorCode = detail.ErrorCode
+		taskError.Message = detail.Message
+		for _, e := range task.TaskErrors {
+			taskError.RecommendedActions = append(taskError.RecommendedActions, e.RecommendedActions...)
+		}
+		if taskError.Message == "" {
+			taskError.Message = task.TaskErrors[0].Message
+		}
+		if taskError.ErrorCode == "" {
+			taskError.ErrorCode = task.TaskErrors[0].ErrorCode
+		}
+	}
+	return taskError
+}
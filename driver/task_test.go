@@ -0,0 +1,102 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ov "github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+)
+
+// Fake HPE OneView which returns task responses in order
+func createTestTaskServer(responses []string) *httptest.Server {
+	count := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/login-sessions":
+			fmt.Fprint(w, `{"sessionID":"test-session"}`)
+		case r.URL.Path == "/rest/sessions/idle-timeout":
+			fmt.Fprint(w, `{"idleTimeout":86400000}`)
+		case strings.HasPrefix(r.URL.Path, "/rest/tasks/"):
+			i := count
+			if i >= len(responses) {
+				i = len(responses) - 1
+			}
+			count++
+			fmt.Fprint(w, responses[i])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func createTestTaskWatcher(endpoint string) *TaskWatcher {
+	ovc := &ov.OVClient{
+		Client: rest.Client{
+			Endpoint:   endpoint,
+			APIVersion: 1200,
+			APIKey:     "none",
+		},
+	}
+	return &TaskWatcher{
+		Client:   ovc,
+		Timeout:  time.Second,
+		Interval: 10 * time.Millisecond,
+	}
+}
+
+func TestTaskWatcherCompleted(t *testing.T) {
+	ts := createTestTaskServer([]string{
+		`{"name":"Create","taskState":"Running","percentComplete":10,"taskStatus":"Apply server settings."}`,
+		`{"name":"Create","taskState":"Running","percentComplete":80,"taskStatus":"Apply server settings."}`,
+		`{"name":"Create","taskState":"Completed","percentComplete":100,"taskStatus":"Created."}`,
+	})
+	defer ts.Close()
+
+	w := createTestTaskWatcher(ts.URL)
+	if err := w.Wait("/rest/tasks/test"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTaskWatcherError(t *testing.T) {
+	ts := createTestTaskServer([]string{
+		`{"name":"Create","taskState":"Error","percentComplete":30,"taskStatus":"Unable to create profile.",
+		  "taskErrors":[{"errorCode":"ProfileCreateFailed","message":"Unable to create profile.",
+		  "recommendedActions":["Verify parameters and try again."],
+		  "nestedErrors":[{"errorCode":"MacTypeDiffGlobalMacType","message":"Mac type should be same as the global Mac assignment."}]}]}`,
+	})
+	defer ts.Close()
+
+	w := createTestTaskWatcher(ts.URL)
+	err := w.Wait("/rest/tasks/test")
+	var taskError *TaskError
+	if !errors.As(err, &taskError) {
+		t.Fatalf("Task error is not returned: %v", err)
+	}
+	if taskError.ErrorCode != "MacTypeDiffGlobalMacType" {
+		t.Fatalf("Nested error code is not reported: %s", taskError.ErrorCode)
+	}
+	if len(taskError.RecommendedActions) != 1 {
+		t.Fatalf("Recommended actions are not reported: %v", taskError.RecommendedActions)
+	}
+	t.Log(err)
+}
+
+func TestTaskWatcherTimeout(t *testing.T) {
+	ts := createTestTaskServer([]string{
+		`{"name":"Delete","taskState":"Running","percentComplete":10,"taskStatus":"Remove server settings."}`,
+	})
+	defer ts.Close()
+
+	w := createTestTaskWatcher(ts.URL)
+	w.Timeout = 50 * time.Millisecond
+	if err := w.Wait("/rest/tasks/test"); err == nil {
+		t.Fatal("Timeout is not detected")
+	}
+}
@@ -0,0 +1,25 @@
+#!/bin/sh
+# Regenerate ubuntu-rr.iso.gz: Ubuntu-like installer tree with Rock Ridge symbolic links
+# written by libarchive (bsdtar), which is independent of ISO9660 writer of this driver.
+set -e
+cd "$(dirname "$0")"
+tree=$(mktemp -d)
+trap 'rm -rf "$tree"' EXIT
+(
+	cd "$tree"
+	mkdir -p dists/focal/main isolinux casper boot/grub
+	printf 'Suite: focal\n' >dists/focal/Release
+	printf 'Packages\n' >dists/focal/main/Packages
+	printf 'kernel\n' >casper/vmlinuz
+	printf 'initrd\n' >casper/initrd
+	head -c 4096 /dev/zero | tr '\0' '\220' >isolinux/isolinux.bin
+	printf 'default live\nlabel live\n  kernel /casper/vmlinuz\n  append initrd=/casper/initrd quiet ---\n' >isolinux/isolinux.cfg
+	ln -s . ubuntu
+	ln -s focal dists/stable
+	ln -s focal dists/unstable-long-name
+	ln -s ../casper/vmlinuz boot/vmlinuz
+	ln -s /casper/initrd isolinux/initrd-abs
+)
+bsdtar -cf - -C "$tree" --format iso9660 \
+	--options 'volume-id=Ubuntu test,boot=isolinux/isolinux.bin,boot-catalog=isolinux/boot.cat,boot-type=no-emulation,boot-info-table,!pad' . |
+	gzip -9 -n >ubuntu-rr.iso.gz
@@ -0,0 +1,155 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/docker/machine/libmachine/log"
+)
+
+const (
+	defaultTlsTimeout  = 10 //sec
+	defaultIdleTimeout = 90 //sec
+)
+
+// TLS certificate verification for HPE OneView and HPE iLO
+type TlsConfig struct {
+	CaCert            string            `yaml:"ca-cert,omitempty"`     // CA bundle path. System CA is used if empty.
+	Fingerprint       string            `yaml:"fingerprint,omitempty"` // Pinned SHA-256 fingerprint of HPE OneView certificate
+	Tofu              bool              `yaml:"tofu,omitempty"`        // Trust certificate on first use and save its fingerprint
+	Insecure          bool              `yaml:"insecure,omitempty"`    // Skip verification
+	KnownFingerprints map[string]string `yaml:"-"`                     // Fingerprints saved by trust on first use
+	mutex             sync.Mutex
+}
+
+// Hosts which were warned in this process
+var insecureHosts sync.Map
+
+// Return TLS config for host. Pinned fingerprint is used instead of CA if it is not empty.
+func (t *TlsConfig) ClientConfig(host, pinned string) (*tls.Config, error) {
+	if t == nil {
+		t = &TlsConfig{}
+	}
+	if t.Insecure {
+		if _, warned := insecureHosts.LoadOrStore(host, true); !warned {
+			log.Warnf("TLS certificate verification is disabled for %s. Credentials may be sent to untrusted server.", host)
+		}
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	var roots *x509.CertPool
+	if pinned == "" && !t.Tofu && t.CaCert != "" {
+		bytes, err := ReadFile(t.CaCert)
+		if err != nil {
+			log.Error(Wrap(err))
+			return nil, err
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(bytes) {
+			err := fmt.Errorf("Could not read CA certificates from %s", t.CaCert)
+			log.Error(Wrap(err))
+			return nil, err
+		}
+	}
+
+	// Verification is done by ourselves to support fingerprint
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return t.verify(host, pinned, roots, rawCerts)
+		},
+	}, nil
+}
+
+func (t *TlsConfig) verify(host, pinned string, roots *x509.CertPool, rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("%s did not present TLS certificate", host)
+	}
+	fingerprint := CertFingerprint(rawCerts[0])
+
+	// Pinned fingerprint
+	if pinned != "" {
+		if normalizeFingerprint(pinned) != normalizeFingerprint(fingerprint) {
+			return fmt.Errorf("TLS certificate fingerprint of %s is %s. It does not match pinned fingerprint", host, fingerprint)
+		}
+		return nil
+	}
+
+	// Trust on first use
+	if t.Tofu {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		if t.KnownFingerprints == nil {
+			t.KnownFingerprints = map[string]string{}
+		}
+		known, ok := t.KnownFingerprints[host]
+		if !ok {
+			log.Infof("Trust TLS certificate of %s on first use: %s", host, fingerprint)
+			t.KnownFingerprints[host] = fingerprint
+			return nil
+		}
+		if normalizeFingerprint(known) != normalizeFingerprint(fingerprint) {
+			return fmt.Errorf("TLS certificate of %s has been changed from %s to %s", host, known, fingerprint)
+		}
+		return nil
+	}
+
+	// CA verification
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       host,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return fmt.Errorf("TLS certificate of %s is not trusted: %v", host, err)
+	}
+	return nil
+}
+
+// Return http client which verifies server certificate
+func (t *TlsConfig) HttpClient(host, pinned string) (*http.Client, error) {
+	tlsConfig, err := t.ClientConfig(host, pinned)
+	if err != nil {
+		log.Error(Wrap(err))
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: defaultTlsTimeout * time.Second,
+			IdleConnTimeout:     defaultIdleTimeout * time.Second,
+		},
+	}, nil
+}
+
+// SHA-256 fingerprint of certificate like AB:CD:...
+func CertFingerprint(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
+
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", " ", "").Replace(fingerprint))
+}
@@ -0,0 +1,152 @@
+package driver
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTlsTestServer(t *testing.T) (*httptest.Server, string) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	return ts, CertFingerprint(ts.Certificate().Raw)
+}
+
+func TestTlsPinnedFingerprint(t *testing.T) {
+	ts, fingerprint := newTlsTestServer(t)
+	defer ts.Close()
+
+	tlsConfig := &TlsConfig{}
+	client, err := tlsConfig.HttpClient("127.0.0.1", fingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err != nil {
+		t.Errorf("Pinned fingerprint should be accepted: %v", err)
+	}
+
+	client, err = tlsConfig.HttpClient("127.0.0.1", "00:11:22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Errorf("Wrong fingerprint should be rejected")
+	}
+}
+
+func TestTlsTofu(t *testing.T) {
+	ts, fingerprint := newTlsTestServer(t)
+	defer ts.Close()
+
+	tlsConfig := &TlsConfig{Tofu: true}
+	client, err := tlsConfig.HttpClient("127.0.0.1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err != nil {
+		t.Fatalf("First connection should be trusted: %v", err)
+	}
+	if tlsConfig.KnownFingerprints["127.0.0.1"] != fingerprint {
+		t.Errorf("Fingerprint is not saved: %v", tlsConfig.KnownFingerprints)
+	}
+
+	// Certificate has been changed
+	tlsConfig.KnownFingerprints["127.0.0.1"] = "00:11:22"
+	client, err = tlsConfig.HttpClient("127.0.0.1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Errorf("Changed certificate should be rejected")
+	}
+}
+
+func TestTlsCaCert(t *testing.T) {
+	ts, _ := newTlsTestServer(t)
+	defer ts.Close()
+
+	// Self-signed certificate is not trusted by system CA
+	tlsConfig := &TlsConfig{}
+	client, err := tlsConfig.HttpClient("127.0.0.1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Errorf("Untrusted certificate should be rejected")
+	}
+
+	dir, err := ioutil.TempDir("", "ov-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caCert := filepath.Join(dir, "ca.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := ioutil.WriteFile(caCert, pemBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig = &TlsConfig{CaCert: caCert}
+	client, err = tlsConfig.HttpClient("127.0.0.1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err != nil {
+		t.Errorf("Certificate signed by CA bundle should be accepted: %v", err)
+	}
+}
+
+func TestTlsInsecure(t *testing.T) {
+	ts, _ := newTlsTestServer(t)
+	defer ts.Close()
+
+	tlsConfig := &TlsConfig{Insecure: true}
+	client, err := tlsConfig.HttpClient("127.0.0.1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ts.URL); err != nil {
+		t.Errorf("Insecure mode should skip verification: %v", err)
+	}
+}
+
+func TestTlsOneviewLogin(t *testing.T) {
+	var logins int
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/login-sessions":
+			logins++
+			w.Write([]byte(`{"sessionID": "session1"}`))
+		default:
+			w.Write([]byte(`{"idleTimeout": 1800000}`))
+		}
+	}))
+	defer ts.Close()
+	fingerprint := CertFingerprint(ts.Certificate().Raw)
+
+	// Credentials are not sent to server which has untrusted certificate
+	o := &Oneview{Endpoint: ts.URL, ApiVersion: 1200, Username: "rancher", Password: "password", Tls: &TlsConfig{Fingerprint: "00:11:22"}}
+	if _, err := o.NewClient(); err == nil {
+		t.Error("Wrong fingerprint should be rejected")
+	}
+	o.Tls = &TlsConfig{}
+	if _, err := o.NewClient(); err == nil {
+		t.Error("Certificate not signed by system CA should be rejected")
+	}
+	if logins != 0 {
+		t.Fatalf("Login request is sent to untrusted server %d times", logins)
+	}
+
+	o.Tls = &TlsConfig{Fingerprint: fingerprint}
+	ovc, err := o.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logins != 1 || ovc.APIKey != "session1" {
+		t.Errorf("Login with pinned fingerprint is not done: %d %s", logins, ovc.APIKey)
+	}
+}
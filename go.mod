@@ -11,5 +11,9 @@ require (
 	github.com/stretchr/testify v1.7.0 // indirect
 	github.com/urfave/cli v1.22.5
 	golang.org/x/crypto v0.0.0-20210616213533-5ff15b29337e // indirect
+	golang.org/x/sys v0.0.0-20210616094352-59db8d763f22
 	gopkg.in/yaml.v2 v2.4.0
 )
+
+// Patched copy which accepts HTTP client verifying TLS certificate
+replace github.com/HewlettPackard/oneview-golang => ./third_party/oneview-golang
@@ -0,0 +1,5 @@
+module github.com/HewlettPackard/oneview-golang
+
+go 1.14
+
+require github.com/docker/machine v0.16.2